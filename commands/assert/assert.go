@@ -27,6 +27,10 @@ func init() {
 	Assert.Flags().StringVarP(&assertQuery, "assert-query", "", "", "the assert-query to get the actual data")
 	Assert.Flags().StringVarP(&assertActual, "assert-actual", "", "", "the assert-actual data file, only JSON file format is supported")
 	Assert.Flags().StringVarP(&assertExpected, "assert-expected", "", "", "the assert-expected data file, only JSON file format is supported")
+	Assert.Flags().StringArrayVar(&config.GlobalCaseFilter.Names, "case", nil,
+		"only assert the cases whose names match the name or glob pattern, can be repeated")
+	Assert.Flags().StringArrayVar(&config.GlobalCaseFilter.Tags, "tag", nil, "only assert the cases with the tag, can be repeated")
+	Assert.Flags().StringArrayVar(&config.GlobalCaseFilter.ExcludeTags, "exclude-tag", nil, "skip the cases with the tag, can be repeated")
 }
 
 var Assert = &cobra.Command{
@@ -34,7 +38,7 @@ var Assert = &cobra.Command{
 	Short: "assert if the actual data match the expected data",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if assertExpected != "" || assertQuery != "" {
-			return assertSingleCase(assertExpected, assertActual, assertQuery)
+			return assertSingleCase(context.Background(), assertExpected, assertActual, assertQuery)
 		}
 		// If there is no given flags.
 		return DoAssertAccordingConfig()
@@ -43,12 +47,11 @@ var Assert = &cobra.Command{
 
 type assertInfo struct {
 	caseNumber int
-	retryCount int
-	interval   time.Duration
+	retry      *config.Retry
 	failFast   bool
 }
 
-func assertSingleCase(expectedFile, actualFile, query string) (err error) {
+func assertSingleCase(ctx context.Context, expectedFile, actualFile, query string) (err error) {
 	defer func() {
 		if r := recover(); r != nil {
 			logger.Log.Error("`assertSingleCase` func throws a panic, we are recover")
@@ -65,7 +68,7 @@ func assertSingleCase(expectedFile, actualFile, query string) (err error) {
 	}()
 
 	if query != "" {
-		err = assert.MetricsAssert(expectedFile, query)
+		err = assert.MetricsAssertContext(ctx, expectedFile, query)
 		if err != nil {
 			return errors.Wrap(err, "assert metrics failed")
 		}
@@ -78,8 +81,23 @@ func assertSingleCase(expectedFile, actualFile, query string) (err error) {
 	return nil
 }
 
+// assertSingleCaseContext asserts a single case, the query of the actual data is canceled when the context is done.
+func assertSingleCaseContext(ctx context.Context, expectedFile, actualFile, query string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("the assertion is abandoned: %v", err)
+	}
+	err := assertSingleCase(ctx, expectedFile, actualFile, query)
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("the assertion is abandoned: %v", ctx.Err())
+	}
+	return err
+}
+
 func concurrentlyAssertSingleCase(ctx context.Context, cancel context.CancelFunc, a *config.AssertCase, info *assertInfo) (res *output.CaseResult) {
+	res = &output.CaseResult{Name: caseName(a)}
+	start := time.Now()
 	defer func() {
+		res.Duration = time.Since(start)
 		if res.Err != nil && info.failFast {
 			cancel()
 		}
@@ -91,42 +109,59 @@ func concurrentlyAssertSingleCase(ctx context.Context, cancel context.CancelFunc
 		return res
 	}
 
-	for current := 0; current <= info.retryCount; current++ {
+	retry, caseCtx, cancelCase, err := info.retry.CaseStrategy(caseName(a), a.Retry, a.Timeout)
+	if err != nil {
+		res.Msg = fmt.Sprintf("failed to assert %v:", caseName(a))
+		res.Err = err
+		return res
+	}
+	defer cancelCase()
+
+	for current := 0; ; current++ {
 		select {
 		case <-ctx.Done():
 			res.Skip = true
 			return res
 		default:
-			if err := assertSingleCase(a.GetExpected(), a.GetActual(), a.Query); err == nil {
+			res.Retries = current
+			attemptStart := time.Now()
+			err = assertSingleCaseContext(caseCtx, a.GetExpected(), a.GetActual(), a.Query)
+			res.AddAttempt(attemptStart, err)
+			if err == nil {
 				if current == 0 {
 					res.Msg = fmt.Sprintf("asserted %v success\n", caseName(a))
 				} else {
 					res.Msg = fmt.Sprintf("asserted %v success, retried %d time(s)\n", caseName(a), current)
 				}
 				return res
-			} else if current != info.retryCount {
-				time.Sleep(info.interval)
-			} else {
-				res.Msg = fmt.Sprintf("failed to assert %v, retried %d time(s):", caseName(a), current)
-				res.Err = err
 			}
+			delay, retried := retry.Next(current+1, start)
+			if retried && caseCtx.Err() == nil && util.SleepContext(caseCtx, delay) {
+				continue
+			}
+			res.Msg = config.CaseFailureMessage(caseCtx, "assert", caseName(a), current)
+			res.Err = err
+			return res
 		}
 	}
-
-	return res
 }
 
 // assertCasesConcurrently assert the cases concurrently.
 func assertCasesConcurrently(a *config.Assert, info *assertInfo) error {
 	res := make([]*output.CaseResult, len(a.Cases))
 	for i := range res {
-		res[i] = &output.CaseResult{}
+		res[i] = &output.CaseResult{Name: caseName(&a.Cases[i])}
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	var wg sync.WaitGroup
 	for idx := range a.Cases {
+		if !config.GlobalCaseFilter.Match(res[idx].Name, a.Cases[idx].Tags) {
+			res[idx].Skip = true
+			res[idx].Msg = fmt.Sprintf("skipped %v, filtered out", res[idx].Name)
+			continue
+		}
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
@@ -145,6 +180,9 @@ func assertCasesConcurrently(a *config.Assert, info *assertInfo) error {
 	wg.Wait()
 
 	_, errNum, _ := printer.PrintResult(res)
+	if err := output.Report("assert", res); err != nil {
+		logger.Log.Warnf("failed to report the assert results: %v", err)
+	}
 	if errNum > 0 {
 		return fmt.Errorf("failed to assert %d case(s)", errNum)
 	}
@@ -158,63 +196,97 @@ func assertCasesSerially(a *config.Assert, info *assertInfo) (err error) {
 	res := make([]*output.CaseResult, len(a.Cases))
 	for i := range res {
 		res[i] = &output.CaseResult{
+			Name: caseName(&a.Cases[i]),
 			Skip: true,
 		}
 	}
 
 	defer func() {
 		_, errNum, _ := printer.PrintResult(res)
+		if reportErr := output.Report("assert", res); reportErr != nil {
+			logger.Log.Warnf("failed to report the assert results: %v", reportErr)
+		}
 		if errNum > 0 {
 			err = fmt.Errorf("failed to assert %d case(s)", errNum)
 		}
 	}()
 
 	for idx := range a.Cases {
-		printer.Start()
 		v := &a.Cases[idx]
+		if !config.GlobalCaseFilter.Match(caseName(v), v.Tags) {
+			res[idx].Msg = fmt.Sprintf("skipped %v, filtered out", caseName(v))
+			continue
+		}
+		printer.Start()
+		start := time.Now()
 
 		if v.GetExpected() == "" {
 			res[idx].Skip = false
 			res[idx].Msg = fmt.Sprintf("failed to assert %v", caseName(v))
 			res[idx].Err = fmt.Errorf("the expected data file for %v is not specified", caseName(v))
 
-			printer.Warning(res[idx].Msg)
-			printer.Fail(res[idx].Err.Error())
+			res[idx].Duration = time.Since(start)
+			printer.FailCase(res[idx])
+			if info.failFast {
+				return
+			}
+			continue
+		}
+
+		retry, caseCtx, cancelCase, e := info.retry.CaseStrategy(caseName(v), v.Retry, v.Timeout)
+		if e != nil {
+			res[idx].Skip = false
+			res[idx].Msg = fmt.Sprintf("failed to assert %v", caseName(v))
+			res[idx].Err = e
+
+			res[idx].Duration = time.Since(start)
+			printer.FailCase(res[idx])
 			if info.failFast {
 				return
 			}
 			continue
 		}
 
-		for current := 0; current <= info.retryCount; current++ {
-			if e := assertSingleCase(v.GetExpected(), v.GetActual(), v.Query); e == nil {
+		for current := 0; ; current++ {
+			res[idx].Retries = current
+			attemptStart := time.Now()
+			e = assertSingleCaseContext(caseCtx, v.GetExpected(), v.GetActual(), v.Query)
+			res[idx].AddAttempt(attemptStart, e)
+			res[idx].Duration = time.Since(start)
+			if e == nil {
 				if current == 0 {
 					res[idx].Msg = fmt.Sprintf("assert %v \n", caseName(v))
 				} else {
 					res[idx].Msg = fmt.Sprintf("assert %v, retried %d time(s)\n", caseName(v), current)
 				}
 				res[idx].Skip = false
-				printer.Success(res[idx].Msg)
+				printer.Success(res[idx].Summary())
 				break
-			} else if current != info.retryCount {
+			}
+			delay, retried := retry.Next(current+1, start)
+			if retried && caseCtx.Err() == nil {
 				if current == 0 {
 					printer.UpdateText(fmt.Sprintf("failed to assert %v, will continue retry:", caseName(v)))
 				} else {
-					printer.UpdateText(fmt.Sprintf("failed to assert %v, retry [%d/%d]", caseName(v), current, info.retryCount))
+					printer.UpdateText(fmt.Sprintf("failed to assert %v, retry [%s]", caseName(v), retry.Progress(current)))
 				}
-				time.Sleep(info.interval)
-			} else {
-				res[idx].Msg = fmt.Sprintf("failed to assert %v, retried %d time(s):", caseName(v), current)
-				res[idx].Err = e
-				res[idx].Skip = false
-				printer.UpdateText(fmt.Sprintf("failed to assert %v, retry [%d/%d]", caseName(v), current, info.retryCount))
-				printer.Warning(res[idx].Msg)
-				printer.Fail(res[idx].Err.Error())
-				if info.failFast {
-					return
+				if util.SleepContext(caseCtx, delay) {
+					continue
 				}
 			}
+			res[idx].Msg = config.CaseFailureMessage(caseCtx, "assert", caseName(v), current)
+			res[idx].Err = e
+			res[idx].Skip = false
+			res[idx].Duration = time.Since(start)
+			printer.UpdateText(fmt.Sprintf("failed to assert %v, retry [%s]", caseName(v), retry.Progress(current)))
+			printer.FailCase(res[idx])
+			cancelCase()
+			if info.failFast {
+				return
+			}
+			break
 		}
+		cancelCase()
 	}
 
 	return nil
@@ -238,21 +310,19 @@ func DoAssertAccordingConfig() error {
 
 	e2eConfig := config.GlobalConfig.E2EConfig
 
-	retryCount := e2eConfig.Assert.RetryStrategy.Count
-	if retryCount <= 0 {
-		retryCount = 1
-	}
-	interval, err := parseInterval(e2eConfig.Assert.RetryStrategy.Interval)
+	retry, err := e2eConfig.Assert.RetryStrategy.Parse("assert.retry")
 	if err != nil {
 		return err
 	}
+	if retry.Count <= 0 && retry.Deadline <= 0 {
+		retry.Count = 1
+	}
 	failFast := e2eConfig.Assert.FailFast
 	caseNumber := len(e2eConfig.Assert.Cases)
 
 	info := assertInfo{
 		caseNumber: caseNumber,
-		retryCount: retryCount,
-		interval:   interval,
+		retry:      retry,
 		failFast:   failFast,
 	}
 
@@ -266,27 +336,3 @@ func DoAssertAccordingConfig() error {
 	printer = output.NewPrinter(util.BatchMode)
 	return assertCasesSerially(&e2eConfig.Assert, &info)
 }
-
-// TODO remove this in 2.0.0
-func parseInterval(retryInterval any) (time.Duration, error) {
-	var interval time.Duration
-	var err error
-	switch itv := retryInterval.(type) {
-	case int:
-		logger.Log.Warnf(`configuring assert.retry.interval with number is deprecated
-and will be removed in future version, please use Duration style instead, such as 10s, 1m.`)
-		interval = time.Duration(itv) * time.Millisecond
-	case string:
-		if interval, err = time.ParseDuration(itv); err != nil {
-			return 0, err
-		}
-	case nil:
-		interval = 0
-	default:
-		return 0, fmt.Errorf("failed to parse assert.retry.interval: %v", retryInterval)
-	}
-	if interval < 0 {
-		interval = 1 * time.Second
-	}
-	return interval, nil
-}
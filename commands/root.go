@@ -26,17 +26,21 @@ import (
 	"github.com/apache/skywalking-infra-e2e/commands/assist"
 	"github.com/apache/skywalking-infra-e2e/commands/cleanup"
 	"github.com/apache/skywalking-infra-e2e/commands/run"
+	"github.com/apache/skywalking-infra-e2e/commands/schema"
 	"github.com/apache/skywalking-infra-e2e/commands/setup"
 	"github.com/apache/skywalking-infra-e2e/commands/trigger"
+	"github.com/apache/skywalking-infra-e2e/commands/validate"
 	"github.com/apache/skywalking-infra-e2e/commands/verify"
 	"github.com/apache/skywalking-infra-e2e/internal/config"
 	"github.com/apache/skywalking-infra-e2e/internal/constant"
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
 	"github.com/apache/skywalking-infra-e2e/internal/util"
+	"github.com/apache/skywalking-infra-e2e/pkg/output"
 )
 
 var (
 	verbosity string
+	reports   []string
 )
 
 // Root represents the base command when called without any subcommands
@@ -67,7 +71,7 @@ var Root = &cobra.Command{
 			return err
 		}
 
-		return nil
+		return output.InitReporters(reports)
 	},
 }
 
@@ -91,6 +95,8 @@ func Execute() error {
 	Root.AddCommand(cleanup.Cleanup)
 	Root.AddCommand(assert.Assert)
 	Root.AddCommand(assist.Assist)
+	Root.AddCommand(validate.Validate)
+	Root.AddCommand(schema.Schema)
 
 	Root.PersistentFlags().StringVarP(&verbosity, "verbosity", "v", logrus.InfoLevel.String(), "log level (debug, info, warn, error, fatal, panic")
 
@@ -100,6 +106,13 @@ func Execute() error {
 	Root.PersistentFlags().BoolVarP(&util.BatchMode, "batch-mode", "B", false,
 		`whether to run in batch mode, if true, all interactive operations are disabled, including real-time progress bar.
 This option is always enabled in concurrency mode and in our GitHub Actions.`)
+	Root.PersistentFlags().StringArrayVar(&util.Overrides, "set", nil,
+		"override the value in the config file in the format of path.to.key=value, such as verify.cases[2].expected=expected.yaml, "+
+			"the indices refer to the items as written in the config file, can be specified multiple times")
+	Root.PersistentFlags().StringArrayVar(&reports, "report", nil,
+		"the report of the verify and assert results in the format of <type>=<path>, can be specified multiple times, supported types: junit")
+	Root.PersistentFlags().IntVar(&output.MaxFailureDiffs, "failure-diffs", output.MaxFailureDiffs,
+		"the max number of the last distinct failure diffs of a failed case to print and report, the final error is always included")
 
 	return Root.Execute()
 }
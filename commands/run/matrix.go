@@ -0,0 +1,125 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package run
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+	"github.com/apache/skywalking-infra-e2e/pkg/output"
+
+	"github.com/pterm/pterm"
+)
+
+// matrixReport is the report of the run with matrix, it contains the reports of all the combinations.
+type matrixReport struct {
+	Config       string       `json:"config"`
+	Start        time.Time    `json:"start"`
+	Duration     int64        `json:"duration-ms"`
+	Success      bool         `json:"success"`
+	Combinations []*runReport `json:"combinations"`
+}
+
+// runMatrix runs the whole process once per combination of the matrix, the combinations are isolated
+// by their own compose projects, kind clusters and log directories, and the results are summarized at last.
+func runMatrix(combinations []*config.Combination) error {
+	report := &matrixReport{Config: util.CfgFile, Start: time.Now()}
+	logDir := util.LogDir
+	defer func() {
+		util.Variant, util.LogDir = "", logDir
+		output.SetVariant("")
+	}()
+
+	failed := 0
+	for idx, combination := range combinations {
+		logger.Log.Infof("running matrix combination %d/%d [%s]", idx+1, len(combinations), combination.Name)
+
+		util.Variant, util.LogDir = combination.ID, filepath.Join(logDir, combination.ID)
+		output.SetVariant(combination.Name)
+		r := newRunReport(util.CfgFile)
+		r.Matrix = combination.Values
+		if err := runCombination(combination, r); err != nil {
+			logger.Log.Errorf("matrix combination [%s] failed: %v", combination.Name, err)
+			failed++
+		}
+		report.Combinations = append(report.Combinations, r)
+	}
+
+	report.Duration = time.Since(report.Start).Milliseconds()
+	report.Success = failed == 0
+	printMatrixSummary(combinations, report.Combinations)
+	if reportPath != "" {
+		if err := writeJSON(reportPath, report); err != nil {
+			logger.Log.Warnf("failed to write the run report: %v", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d matrix combination(s) failed", failed, len(combinations))
+	}
+	return nil
+}
+
+// runCombination exports the matrix values and reloads the config with them, then runs the whole process.
+// The environment variables exported during the run are discarded, so the combinations don't affect each other.
+func runCombination(combination *config.Combination, report *runReport) error {
+	defer util.RestoreEnv(os.Environ())
+
+	if err := combination.Export(); err != nil {
+		report.finish(nil, err)
+		return err
+	}
+	// the config is expanded in place, so it's reloaded to expand with the values of this combination.
+	config.ReadGlobalConfigFile()
+	return runAccordingE2E(report)
+}
+
+func printMatrixSummary(combinations []*config.Combination, reports []*runReport) {
+	pterm.Info.Prefix = pterm.Prefix{
+		Text:  "MATRIX",
+		Style: &pterm.ThemeDefault.InfoPrefixStyle,
+	}
+	for idx, r := range reports {
+		passNum, failNum, skipNum := 0, 0, 0
+		for _, s := range r.Suites {
+			for _, c := range s.Cases {
+				switch {
+				case c.Skipped:
+					skipNum++
+				case c.Success:
+					passNum++
+				default:
+					failNum++
+				}
+			}
+		}
+
+		msg := fmt.Sprintf("%s: %d passed, %d failed, %d skipped (%s)", combinations[idx].Name, passNum, failNum, skipNum,
+			time.Duration(r.Duration)*time.Millisecond)
+		if r.Success {
+			pterm.Info.WithMessageStyle(&pterm.Style{pterm.FgGreen}).Println(msg)
+		} else {
+			pterm.Info.WithMessageStyle(&pterm.Style{pterm.FgLightRed}).Println(fmt.Sprintf("%s, %s", msg, r.Error))
+		}
+	}
+	fmt.Println()
+}
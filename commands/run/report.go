@@ -0,0 +1,234 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/commands/trigger"
+	"github.com/apache/skywalking-infra-e2e/internal/components/setup"
+	"github.com/apache/skywalking-infra-e2e/pkg/output"
+)
+
+const maskedValue = "******"
+
+// secretEnvPattern matches the names of the environment variables whose values should be masked in the report.
+var secretEnvPattern = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|private|api_?key|access_?key)`)
+
+// runReport is the machine-readable report of the whole run, it's written in JSON format.
+type runReport struct {
+	lock sync.Mutex
+	// environ is the environment variables before the run, used to find out the exported ones.
+	environ map[string]string
+
+	Config   string            `json:"config"`
+	Matrix   map[string]string `json:"matrix,omitempty"`
+	Start    time.Time         `json:"start"`
+	Duration int64             `json:"duration-ms"`
+	Success  bool              `json:"success"`
+	Error    string            `json:"error,omitempty"`
+	Setup    *setupReport      `json:"setup,omitempty"`
+	Triggers []*triggerReport  `json:"triggers"`
+	Suites   []*suiteReport    `json:"suites"`
+	Cleanup  *cleanupReport    `json:"cleanup"`
+	Env      map[string]string `json:"env"`
+}
+
+type setupReport struct {
+	Duration int64         `json:"duration-ms"`
+	Error    string        `json:"error,omitempty"`
+	Steps    []*stepReport `json:"steps"`
+}
+
+type stepReport struct {
+	Name     string    `json:"name"`
+	Start    time.Time `json:"start"`
+	Duration int64     `json:"duration-ms"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type triggerReport struct {
+	Name      string `json:"name"`
+	Error     string `json:"error,omitempty"`
+	Sent      int64  `json:"sent"`
+	Succeeded int64  `json:"succeeded"`
+	Failed    int64  `json:"failed"`
+	P50       int64  `json:"latency-p50-ms"`
+	P90       int64  `json:"latency-p90-ms"`
+	P99       int64  `json:"latency-p99-ms"`
+}
+
+type suiteReport struct {
+	Name  string        `json:"name"`
+	Cases []*caseReport `json:"cases"`
+}
+
+type caseReport struct {
+	Name     string           `json:"name"`
+	Skipped  bool             `json:"skipped"`
+	Success  bool             `json:"success"`
+	Retries  int              `json:"retries"`
+	Duration int64            `json:"duration-ms"`
+	Message  string           `json:"message,omitempty"`
+	Error    string           `json:"error,omitempty"`
+	Attempts []*attemptReport `json:"attempts"`
+}
+
+type attemptReport struct {
+	Start    time.Time `json:"start"`
+	Duration int64     `json:"duration-ms"`
+	Error    string    `json:"error,omitempty"`
+}
+
+type cleanupReport struct {
+	Executed bool   `json:"executed"`
+	Duration int64  `json:"duration-ms"`
+	Error    string `json:"error,omitempty"`
+}
+
+func newRunReport(config string) *runReport {
+	environ := make(map[string]string)
+	for _, kv := range os.Environ() {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			environ[k] = v
+		}
+	}
+	return &runReport{
+		environ: environ,
+		Config:  config,
+		Start:   time.Now(),
+		Cleanup: &cleanupReport{},
+	}
+}
+
+// Report adds the case results of the assert or verify suite to the report, it implements output.Reporter.
+func (r *runReport) Report(suite string, results []*output.CaseResult) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s := &suiteReport{Name: suite, Cases: make([]*caseReport, 0, len(results))}
+	for _, result := range results {
+		c := &caseReport{
+			Name:     result.Name,
+			Skipped:  result.Skip,
+			Success:  !result.Skip && result.Err == nil,
+			Retries:  result.Retries,
+			Duration: result.Duration.Milliseconds(),
+			Message:  strings.TrimSpace(result.Msg),
+			Error:    errorString(result.Err),
+		}
+		for _, attempt := range result.Attempts {
+			c.Attempts = append(c.Attempts, &attemptReport{
+				Start:    attempt.Start,
+				Duration: attempt.Duration.Milliseconds(),
+				Error:    errorString(attempt.Err),
+			})
+		}
+		s.Cases = append(s.Cases, c)
+	}
+	r.Suites = append(r.Suites, s)
+	return nil
+}
+
+func (r *runReport) setup(start time.Time, err error) {
+	r.Setup = &setupReport{Duration: time.Since(start).Milliseconds(), Error: errorString(err)}
+	for _, step := range setup.StepResults() {
+		r.Setup.Steps = append(r.Setup.Steps, &stepReport{
+			Name:     step.Name,
+			Start:    step.Start,
+			Duration: step.Duration.Milliseconds(),
+			Error:    errorString(step.Err),
+		})
+	}
+}
+
+func (r *runReport) cleanup(start time.Time, err error) {
+	r.Cleanup = &cleanupReport{Executed: true, Duration: time.Since(start).Milliseconds(), Error: errorString(err)}
+}
+
+// finish completes the report with the result of the run, the triggers and the exported environment variables.
+func (r *runReport) finish(actions []*trigger.NamedAction, err error) {
+	r.Duration = time.Since(r.Start).Milliseconds()
+	r.Success = err == nil
+	r.Error = errorString(err)
+
+	for _, action := range actions {
+		stats := action.Stats().Snapshot()
+		r.Triggers = append(r.Triggers, &triggerReport{
+			Name:      action.Name,
+			Error:     errorString(action.Err),
+			Sent:      stats.Sent,
+			Succeeded: stats.Succeeded,
+			Failed:    stats.Failed,
+			P50:       stats.P50.Milliseconds(),
+			P90:       stats.P90.Milliseconds(),
+			P99:       stats.P99.Milliseconds(),
+		})
+	}
+
+	r.Env = make(map[string]string)
+	for _, kv := range os.Environ() {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			continue
+		}
+		if before, exist := r.environ[k]; exist && before == v {
+			continue
+		}
+		if secretEnvPattern.MatchString(k) {
+			v = maskedValue
+		}
+		r.Env[k] = v
+	}
+}
+
+// write writes the report to the path in JSON format.
+func (r *runReport) write(path string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	return writeJSON(path, r)
+}
+
+// writeJSON writes the report to the path in JSON format.
+func writeJSON(path string, report any) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal run report: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create run report directory: %v", err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write run report %s: %v", path, err)
+	}
+	return nil
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -0,0 +1,68 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package run
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/pkg/output"
+)
+
+func TestRunReport(t *testing.T) {
+	t.Setenv("E2E_REPORT_UNCHANGED", "value")
+	report := newRunReport("e2e.yaml")
+
+	t.Setenv("E2E_REPORT_PORT", "8080")
+	t.Setenv("E2E_REPORT_PASSWORD", "secret")
+	_ = report.Report("verify", []*output.CaseResult{
+		{Name: "case-1", Retries: 1, Duration: time.Second},
+		{Name: "case-2", Msg: "failed to verify case-2", Err: fmt.Errorf("diff")},
+	})
+	report.finish(nil, fmt.Errorf("failed to verify 1 case(s)"))
+
+	path := filepath.Join(t.TempDir(), "report.json")
+	if err := report.write(path); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	result := &runReport{}
+	if err := json.Unmarshal(data, result); err != nil {
+		t.Fatalf("failed to parse the report: %v", err)
+	}
+
+	if result.Success || result.Error != "failed to verify 1 case(s)" {
+		t.Errorf("unexpected result: success %v, error %s", result.Success, result.Error)
+	}
+	if _, exist := result.Env["E2E_REPORT_UNCHANGED"]; exist {
+		t.Errorf("the environment variable not exported during the run should not be reported")
+	}
+	if result.Env["E2E_REPORT_PORT"] != "8080" || result.Env["E2E_REPORT_PASSWORD"] != maskedValue {
+		t.Errorf("unexpected env: %v", result.Env)
+	}
+	cases := result.Suites[0].Cases
+	if !cases[0].Success || cases[0].Retries != 1 || cases[0].Duration != 1000 || cases[1].Success || cases[1].Error != "diff" {
+		t.Errorf("unexpected cases: %+v, %+v", cases[0], cases[1])
+	}
+}
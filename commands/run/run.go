@@ -17,51 +17,76 @@
 package run
 
 import (
+	"time"
+
 	"github.com/apache/skywalking-infra-e2e/commands/assert"
 	"github.com/apache/skywalking-infra-e2e/commands/cleanup"
 	"github.com/apache/skywalking-infra-e2e/commands/setup"
 	"github.com/apache/skywalking-infra-e2e/commands/trigger"
 	"github.com/apache/skywalking-infra-e2e/commands/verify"
-	t "github.com/apache/skywalking-infra-e2e/internal/components/trigger"
 	"github.com/apache/skywalking-infra-e2e/internal/config"
 	"github.com/apache/skywalking-infra-e2e/internal/constant"
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+	"github.com/apache/skywalking-infra-e2e/pkg/output"
 
 	"github.com/spf13/cobra"
 )
 
+var reportPath string
+
+func init() {
+	Run.Flags().StringVar(&reportPath, "json-report", "", "the path of the JSON report of the whole run, no report is written if it's empty")
+	Run.Flags().StringArrayVar(&config.GlobalCaseFilter.Names, "case", nil,
+		"only run the cases whose names match the name or glob pattern, can be repeated")
+	Run.Flags().StringArrayVar(&config.GlobalCaseFilter.Tags, "tag", nil, "only run the cases with the tag, can be repeated")
+	Run.Flags().StringArrayVar(&config.GlobalCaseFilter.ExcludeTags, "exclude-tag", nil, "skip the cases with the tag, can be repeated")
+}
+
 var Run = &cobra.Command{
 	Use:   "run",
 	Short: "",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		err := runAccordingE2E()
-		if err != nil {
-			return err
+		if config.GlobalConfig.Error != nil {
+			return config.GlobalConfig.Error
+		}
+		if combinations := config.GlobalConfig.E2EConfig.Matrix.Combinations(); len(combinations) > 0 {
+			return runMatrix(combinations)
 		}
 
-		return nil
+		report := newRunReport(util.CfgFile)
+		err := runAccordingE2E(report)
+		if reportPath != "" {
+			if e := report.write(reportPath); e != nil {
+				logger.Log.Warnf("failed to write the run report: %v", e)
+			}
+		}
+		return err
 	},
 }
 
-func runAccordingE2E() error {
+func runAccordingE2E(report *runReport) (err error) {
+	var actions []*trigger.NamedAction
+	output.AddReporter(report)
+	defer func() {
+		output.RemoveReporter(report)
+		report.finish(actions, err)
+	}()
+
 	if config.GlobalConfig.Error != nil {
 		return config.GlobalConfig.Error
 	}
 
-	var action t.Action
-	stopAction := func() {
-		if action != nil {
-			action.Stop()
-		}
-	}
 	// If cleanup.on == Always and there is error in setup step, we should defer cleanup step right now.
 	cleanupOnCondition := config.GlobalConfig.E2EConfig.Cleanup.On
 	if cleanupOnCondition == constant.CleanUpAlways {
-		defer doCleanup(stopAction)
+		defer doCleanup(report)
 	}
 
 	// setup part
-	err := setup.DoSetupAccordingE2E()
+	setupStart := time.Now()
+	err = setup.DoSetupAccordingE2E()
+	report.setup(setupStart, err)
 	if err != nil {
 		return err
 	}
@@ -77,17 +102,19 @@ func runAccordingE2E() error {
 				return
 			}
 
-			doCleanup(stopAction)
+			doCleanup(report)
 		}()
 	}
 
 	// trigger part
-	action, err = trigger.CreateTriggerAction()
+	actions, err = trigger.CreateTriggerActions()
 	if err != nil {
 		return err
 	}
-	if action != nil {
-		err = <-action.Do()
+	// the actions are always stopped before the cleanup, no matter whether the cleanup is going to happen.
+	defer trigger.StopTriggerActions(actions)
+	if len(actions) > 0 {
+		err = trigger.DoTriggerActions(actions)
 		if err != nil {
 			return err
 		}
@@ -110,12 +137,12 @@ func runAccordingE2E() error {
 	return nil
 }
 
-func doCleanup(stopAction func()) {
-	if stopAction != nil {
-		stopAction()
-	}
+func doCleanup(report *runReport) {
 	setup.DoStopSetup()
-	if err := cleanup.DoCleanupAccordingE2E(); err != nil {
+	start := time.Now()
+	err := cleanup.DoCleanupAccordingE2E()
+	report.cleanup(start, err)
+	if err != nil {
 		logger.Log.Errorf("cleanup part error: %s", err)
 	} else {
 		logger.Log.Infof("cleanup part finished successfully")
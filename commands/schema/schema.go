@@ -0,0 +1,68 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+)
+
+var (
+	reusingCases       bool
+	reusingAssertCases bool
+	outputFile         string
+)
+
+func init() {
+	Schema.Flags().BoolVarP(&reusingCases, "reusing-cases", "", false, "generate the schema of the reusing verify cases file instead of e2e.yaml")
+	Schema.Flags().BoolVarP(&reusingAssertCases, "reusing-assert-cases", "", false,
+		"generate the schema of the reusing assert cases file instead of e2e.yaml")
+	Schema.Flags().StringVarP(&outputFile, "output", "o", "", "the file to write the schema to, the schema is printed if it's empty")
+}
+
+var Schema = &cobra.Command{
+	Use:   "schema",
+	Short: "generate the JSON Schema of the e2e config file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		s := config.E2EConfigSchema()
+		switch {
+		case reusingCases && reusingAssertCases:
+			return fmt.Errorf("--reusing-cases and --reusing-assert-cases could not be used together")
+		case reusingCases:
+			s = config.ReusingCasesSchema()
+		case reusingAssertCases:
+			s = config.ReusingAssertCasesSchema()
+		}
+
+		data, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal the schema: %v", err)
+		}
+		data = append(data, '\n')
+
+		if outputFile == "" {
+			_, err = os.Stdout.Write(data)
+			return err
+		}
+		return os.WriteFile(outputFile, data, 0o600)
+	},
+}
@@ -62,6 +62,7 @@ func DoSetupAccordingE2E() error {
 
 	e2eConfig := config.GlobalConfig.E2EConfig
 
+	setup.ResetStepResults()
 	setup.InitLogFollower()
 	if e2eConfig.Setup.Env == constant.Kind {
 		err := setup.KindSetup(&e2eConfig)
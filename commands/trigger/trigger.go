@@ -18,28 +18,48 @@ package trigger
 
 import (
 	"fmt"
+	"os"
+	"regexp"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/apache/skywalking-infra-e2e/internal/components/trigger"
 	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
 	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
 
-	"github.com/apache/skywalking-infra-e2e/internal/constant"
+// statsExportInterval is the interval to refresh the exported statistics of the running actions.
+const statsExportInterval = time.Second
+
+var invalidEnvChars = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// statsExporter refreshes the exported statistics of the running actions periodically.
+type statsExporter struct {
+	stop chan struct{}
+	done chan struct{}
+}
+
+var (
+	statsExporterLock sync.Mutex
+	runningExporter   *statsExporter
 )
 
 var Trigger = &cobra.Command{
 	Use: "trigger",
 	RunE: func(cmd *cobra.Command, args []string) error {
-		action, err := CreateTriggerAction()
+		actions, err := CreateTriggerActions()
 		if err != nil {
 			return fmt.Errorf("[Trigger] %v", err)
 		}
-		if action == nil {
+		if len(actions) == 0 {
 			return nil
 		}
-		if err := <-action.Do(); err != nil {
+		if err := DoTriggerActions(actions); err != nil {
 			return err
 		}
 
@@ -48,24 +68,169 @@ var Trigger = &cobra.Command{
 		util.AddShutDownHook(wg.Done)
 		wg.Wait()
 
-		action.Stop()
+		StopTriggerActions(actions)
 		return nil
 	},
 }
 
-func CreateTriggerAction() (trigger.Action, error) {
+// NamedAction is a trigger action with the name configured in e2e.yaml.
+type NamedAction struct {
+	Name string
+	// Err is the first result of the action, it's set after the action is done.
+	Err error
+	trigger.Action
+}
+
+// CreateTriggerActions creates all the trigger actions configured in e2e.yaml,
+// the triggers without action are ignored.
+func CreateTriggerActions() ([]*NamedAction, error) {
 	if err := config.GlobalConfig.Error; err != nil {
 		return nil, err
 	}
 
-	switch t := config.GlobalConfig.E2EConfig.Trigger; t.Action {
+	triggers := config.GlobalConfig.E2EConfig.Trigger
+	if err := triggers.ExpandEnv(); err != nil {
+		return nil, err
+	}
+	actions := make([]*NamedAction, 0, len(triggers))
+	for idx := range triggers {
+		action, err := CreateTriggerAction(&triggers[idx])
+		if err != nil {
+			return nil, fmt.Errorf("create trigger %s error: %v", triggers[idx].Name, err)
+		}
+		if action == nil {
+			continue
+		}
+		actions = append(actions, &NamedAction{Name: triggers[idx].Name, Action: action})
+	}
+	return actions, nil
+}
+
+func CreateTriggerAction(t *config.Trigger) (trigger.Action, error) {
+	switch t.Action {
 	case "":
 		return nil, nil
 	case constant.ActionHTTP:
-		return trigger.NewHTTPAction(t.Interval, t.Times, t.URL, t.Method, t.Body, t.Headers)
+		if t.Load != nil {
+			return trigger.NewHTTPLoadAction(t.Times, t.URL, t.Method, t.Body, t.Headers, t.Template,
+				(*trigger.ExpectOptions)(t.Expect), trigger.LoadOptions(*t.Load))
+		}
+		return trigger.NewHTTPAction(t.Interval, t.Times, t.URL, t.Method, t.Body, t.Headers, t.Template, (*trigger.ExpectOptions)(t.Expect))
 	case constant.ActionHeraHTTP:
-		return trigger.NewHeraHTTPAction(t.Interval, t.Times, t.URL, t.Method, t.Body, t.Headers)
+		return trigger.NewHeraHTTPAction(t.Interval, t.Times, t.URL, t.Method, t.Body, t.Headers, t.Template, (*trigger.ExpectOptions)(t.Expect))
+	case constant.ActionCMD:
+		return trigger.NewCMDAction(t.Interval, t.Times, t.Command)
+	case constant.ActionGRPC:
+		return trigger.NewGRPCAction(t.Interval, t.Times, t.URL, t.Method, t.Body, t.Headers, t.Template, util.ResolveAbs(t.DescriptorSet))
 	default:
 		return nil, fmt.Errorf("unsupported trigger action: %s", t.Action)
 	}
 }
+
+// DoTriggerActions starts all the actions together, and waits until all of them report the first result.
+// The first error is returned if any action fails.
+func DoTriggerActions(actions []*NamedAction) error {
+	results := make([]chan error, len(actions))
+	for idx, action := range actions {
+		logger.Log.Infof("starting trigger %s", action.Name)
+		results[idx] = action.Do()
+	}
+
+	var firstErr error
+	// every result should be received, otherwise the action can not be stopped.
+	for idx, result := range results {
+		if err := <-result; err != nil {
+			actions[idx].Err = err
+			logger.Log.Errorf("trigger %s failed: %v", actions[idx].Name, err)
+			if firstErr == nil {
+				firstErr = fmt.Errorf("trigger %s failed: %v", actions[idx].Name, err)
+			}
+			continue
+		}
+		logger.Log.Infof("trigger %s finished successfully", actions[idx].Name)
+	}
+
+	// keep the exported statistics up to date while the actions are running.
+	startStatsExporter(actions)
+
+	return firstErr
+}
+
+// startStatsExporter exports the statistics of the actions until stopStatsExporter is called,
+// the exporter of the previous actions is stopped first if it's still running.
+func startStatsExporter(actions []*NamedAction) {
+	statsExporterLock.Lock()
+	defer statsExporterLock.Unlock()
+
+	stopRunningExporter()
+	exportTriggerStats(actions, false)
+	exporter := &statsExporter{stop: make(chan struct{}), done: make(chan struct{})}
+	go func() {
+		defer close(exporter.done)
+		t := time.NewTicker(statsExportInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-t.C:
+				exportTriggerStats(actions, false)
+			case <-exporter.stop:
+				return
+			}
+		}
+	}()
+	runningExporter = exporter
+}
+
+// stopStatsExporter stops the running exporter, and waits until it exits.
+func stopStatsExporter() {
+	statsExporterLock.Lock()
+	defer statsExporterLock.Unlock()
+
+	stopRunningExporter()
+}
+
+func stopRunningExporter() {
+	if runningExporter == nil {
+		return
+	}
+	close(runningExporter.stop)
+	<-runningExporter.done
+	runningExporter = nil
+}
+
+// StopTriggerActions stops all the started actions, and exports the final statistics.
+func StopTriggerActions(actions []*NamedAction) {
+	stopStatsExporter()
+	for _, action := range actions {
+		action.Stop()
+	}
+	exportTriggerStats(actions, true)
+}
+
+// exportTriggerStats exports the statistics of the actions to the environment variables,
+// so that they can be referenced in the verify and assert stages, the format is `TRIGGER_<name>_<stat>`,
+// and all the characters of the name which are not allowed in environment variable are replaced with `_`.
+func exportTriggerStats(actions []*NamedAction, final bool) {
+	for _, action := range actions {
+		stats := action.Stats().Snapshot()
+		prefix := "TRIGGER_" + invalidEnvChars.ReplaceAllString(action.Name, "_")
+		values := map[string]int64{
+			prefix + "_SENT":        stats.Sent,
+			prefix + "_SUCCESS":     stats.Succeeded,
+			prefix + "_FAILED":      stats.Failed,
+			prefix + "_LATENCY_P50": stats.P50.Milliseconds(),
+			prefix + "_LATENCY_P90": stats.P90.Milliseconds(),
+			prefix + "_LATENCY_P99": stats.P99.Milliseconds(),
+		}
+		for key, value := range values {
+			if err := os.Setenv(key, strconv.FormatInt(value, 10)); err != nil {
+				logger.Log.Warnf("failed to export trigger statistics %s=%d, %v", key, value, err)
+			}
+		}
+
+		if final {
+			logger.Log.Infof("trigger %s sent %d, succeeded %d, failed %d, latency p50 %s, p90 %s, p99 %s",
+				action.Name, stats.Sent, stats.Succeeded, stats.Failed, stats.P50, stats.P90, stats.P99)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/components/trigger"
+)
+
+type fakeAction struct {
+	stats trigger.Stats
+}
+
+func (f *fakeAction) Do() chan error {
+	result := make(chan error, 1)
+	result <- nil
+	return result
+}
+
+func (f *fakeAction) Stop() {}
+
+func (f *fakeAction) Stats() *trigger.Stats {
+	return &f.stats
+}
+
+func TestDoTriggerActions_StopsPreviousExporter(t *testing.T) {
+	first := []*NamedAction{{Name: "first", Action: &fakeAction{}}}
+	if err := DoTriggerActions(first); err != nil {
+		t.Fatalf("DoTriggerActions() error = %v", err)
+	}
+	previous := runningExporter
+
+	second := []*NamedAction{{Name: "second", Action: &fakeAction{}}}
+	if err := DoTriggerActions(second); err != nil {
+		t.Fatalf("DoTriggerActions() error = %v", err)
+	}
+	select {
+	case <-previous.done:
+	case <-time.After(time.Second):
+		t.Fatal("the exporter of the previous actions is still running")
+	}
+
+	StopTriggerActions(second)
+	if runningExporter != nil {
+		t.Fatal("the exporter is still running after the actions are stopped")
+	}
+}
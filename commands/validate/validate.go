@@ -0,0 +1,44 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package validate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+var Validate = &cobra.Command{
+	Use:   "validate",
+	Short: "strictly validate the e2e config file and report all the problems",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		problems := config.Validate(util.CfgFile)
+		if len(problems) == 0 {
+			logger.Log.Infof("the e2e config file %s is valid", util.CfgFile)
+			return nil
+		}
+
+		for _, problem := range problems {
+			fmt.Println(problem)
+		}
+		return fmt.Errorf("found %d problem(s) in the e2e config file %s", len(problems), util.CfgFile)
+	},
+}
@@ -43,6 +43,10 @@ func init() {
 	Verify.Flags().StringVarP(&query, "query", "q", "", "the query to get the actual data, the result of the query should in YAML format")
 	Verify.Flags().StringVarP(&actual, "actual", "a", "", "the actual data file, only YAML file format is supported")
 	Verify.Flags().StringVarP(&expected, "expected", "e", "", "the expected data file, only YAML file format is supported")
+	Verify.Flags().StringArrayVar(&config.GlobalCaseFilter.Names, "case", nil,
+		"only verify the cases whose names match the name or glob pattern, can be repeated")
+	Verify.Flags().StringArrayVar(&config.GlobalCaseFilter.Tags, "tag", nil, "only verify the cases with the tag, can be repeated")
+	Verify.Flags().StringArrayVar(&config.GlobalCaseFilter.ExcludeTags, "exclude-tag", nil, "skip the cases with the tag, can be repeated")
 }
 
 // Verify verifies that the actual data satisfies the expected data pattern.
@@ -51,7 +55,7 @@ var Verify = &cobra.Command{
 	Short: "verify if the actual data match the expected data",
 	RunE: func(cmd *cobra.Command, args []string) error {
 		if expected != "" {
-			return verifySingleCase(expected, actual, query)
+			return verifySingleCase(context.Background(), expected, actual, query)
 		}
 
 		// If there is no given flags.
@@ -62,12 +66,11 @@ var Verify = &cobra.Command{
 // verifyInfo contains necessary information about verification
 type verifyInfo struct {
 	caseNumber int
-	retryCount int
-	interval   time.Duration
+	retry      *config.Retry
 	failFast   bool
 }
 
-func verifySingleCase(expectedFile, actualFile, query string) error {
+func verifySingleCase(ctx context.Context, expectedFile, actualFile, query string) error {
 	expectedData, err := util.ReadFileContent(expectedFile)
 	if err != nil {
 		return fmt.Errorf("failed to read the expected data file: %v", err)
@@ -82,9 +85,9 @@ func verifySingleCase(expectedFile, actualFile, query string) error {
 		}
 	} else if query != "" {
 		sourceName = query
-		actualData, stderr, err = util.ExecuteCommand(query)
+		actualData, stderr, err = util.ExecuteCommandContext(ctx, query)
 		if err != nil {
-			return fmt.Errorf("failed to execute the query: %s, output: %s, error: %v", query, actualData, stderr)
+			return fmt.Errorf("failed to execute the query: %s, output: %s, error: %v, stderr: %s", query, actualData, err, stderr)
 		}
 	}
 
@@ -105,8 +108,10 @@ func concurrentlyVerifySingleCase(
 	v *config.VerifyCase,
 	verifyInfo *verifyInfo,
 ) (res *output.CaseResult) {
-	res = &output.CaseResult{}
+	res = &output.CaseResult{Name: caseName(v)}
+	start := time.Now()
 	defer func() {
+		res.Duration = time.Since(start)
 		if res.Err != nil && verifyInfo.failFast {
 			cancel()
 		}
@@ -118,42 +123,59 @@ func concurrentlyVerifySingleCase(
 		return res
 	}
 
-	for current := 0; current <= verifyInfo.retryCount; current++ {
+	retry, caseCtx, cancelCase, err := verifyInfo.retry.CaseStrategy(caseName(v), v.Retry, v.Timeout)
+	if err != nil {
+		res.Msg = fmt.Sprintf("failed to verify %v:", caseName(v))
+		res.Err = err
+		return res
+	}
+	defer cancelCase()
+
+	for current := 0; ; current++ {
 		select {
 		case <-ctx.Done():
 			res.Skip = true
 			return res
 		default:
-			if err := verifySingleCase(v.GetExpected(), v.GetActual(), v.Query); err == nil {
+			res.Retries = current
+			attemptStart := time.Now()
+			err = verifySingleCase(caseCtx, v.GetExpected(), v.GetActual(), v.Query)
+			res.AddAttempt(attemptStart, err)
+			if err == nil {
 				if current == 0 {
 					res.Msg = fmt.Sprintf("verified %v\n", caseName(v))
 				} else {
 					res.Msg = fmt.Sprintf("verified %v, retried %d time(s)\n", caseName(v), current)
 				}
 				return res
-			} else if current != verifyInfo.retryCount {
-				time.Sleep(verifyInfo.interval)
-			} else {
-				res.Msg = fmt.Sprintf("failed to verify %v, retried %d time(s):", caseName(v), current)
-				res.Err = err
 			}
+			delay, retried := retry.Next(current+1, start)
+			if retried && caseCtx.Err() == nil && util.SleepContext(caseCtx, delay) {
+				continue
+			}
+			res.Msg = config.CaseFailureMessage(caseCtx, "verify", caseName(v), current)
+			res.Err = err
+			return res
 		}
 	}
-
-	return res
 }
 
 // verifyCasesConcurrently verifies the cases concurrently.
 func verifyCasesConcurrently(verify *config.Verify, verifyInfo *verifyInfo) error {
 	res := make([]*output.CaseResult, len(verify.Cases))
 	for i := range res {
-		res[i] = &output.CaseResult{}
+		res[i] = &output.CaseResult{Name: caseName(&verify.Cases[i])}
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	var wg sync.WaitGroup
 	for idx := range verify.Cases {
+		if !config.GlobalCaseFilter.Match(res[idx].Name, verify.Cases[idx].Tags) {
+			res[idx].Skip = true
+			res[idx].Msg = fmt.Sprintf("skipped %v, filtered out", res[idx].Name)
+			continue
+		}
 		wg.Add(1)
 		go func(i int) {
 			defer wg.Done()
@@ -172,6 +194,9 @@ func verifyCasesConcurrently(verify *config.Verify, verifyInfo *verifyInfo) erro
 	wg.Wait()
 
 	_, errNum, _ := printer.PrintResult(res)
+	if err := output.Report("verify", res); err != nil {
+		logger.Log.Warnf("failed to report the verify results: %v", err)
+	}
 	if errNum > 0 {
 		return fmt.Errorf("failed to verify %d case(s)", errNum)
 	}
@@ -185,63 +210,97 @@ func verifyCasesSerially(verify *config.Verify, verifyInfo *verifyInfo) (err err
 	res := make([]*output.CaseResult, len(verify.Cases))
 	for i := range res {
 		res[i] = &output.CaseResult{
+			Name: caseName(&verify.Cases[i]),
 			Skip: true,
 		}
 	}
 
 	defer func() {
 		_, errNum, _ := printer.PrintResult(res)
+		if reportErr := output.Report("verify", res); reportErr != nil {
+			logger.Log.Warnf("failed to report the verify results: %v", reportErr)
+		}
 		if errNum > 0 {
 			err = fmt.Errorf("failed to verify %d case(s)", errNum)
 		}
 	}()
 
 	for idx := range verify.Cases {
-		printer.Start()
 		v := &verify.Cases[idx]
+		if !config.GlobalCaseFilter.Match(caseName(v), v.Tags) {
+			res[idx].Msg = fmt.Sprintf("skipped %v, filtered out", caseName(v))
+			continue
+		}
+		printer.Start()
+		start := time.Now()
 
 		if v.GetExpected() == "" {
 			res[idx].Skip = false
 			res[idx].Msg = fmt.Sprintf("failed to verify %v", caseName(v))
 			res[idx].Err = fmt.Errorf("the expected data file for %v is not specified", caseName(v))
 
-			printer.Warning(res[idx].Msg)
-			printer.Fail(res[idx].Err.Error())
+			res[idx].Duration = time.Since(start)
+			printer.FailCase(res[idx])
 			if verifyInfo.failFast {
 				return
 			}
 			continue
 		}
 
-		for current := 0; current <= verifyInfo.retryCount; current++ {
-			if e := verifySingleCase(v.GetExpected(), v.GetActual(), v.Query); e == nil {
+		retry, caseCtx, cancelCase, e := verifyInfo.retry.CaseStrategy(caseName(v), v.Retry, v.Timeout)
+		if e != nil {
+			res[idx].Skip = false
+			res[idx].Msg = fmt.Sprintf("failed to verify %v", caseName(v))
+			res[idx].Err = e
+
+			res[idx].Duration = time.Since(start)
+			printer.FailCase(res[idx])
+			if verifyInfo.failFast {
+				return
+			}
+			continue
+		}
+
+		for current := 0; ; current++ {
+			res[idx].Retries = current
+			attemptStart := time.Now()
+			e = verifySingleCase(caseCtx, v.GetExpected(), v.GetActual(), v.Query)
+			res[idx].AddAttempt(attemptStart, e)
+			res[idx].Duration = time.Since(start)
+			if e == nil {
 				if current == 0 {
 					res[idx].Msg = fmt.Sprintf("verified %v \n", caseName(v))
 				} else {
 					res[idx].Msg = fmt.Sprintf("verified %v, retried %d time(s)\n", caseName(v), current)
 				}
 				res[idx].Skip = false
-				printer.Success(res[idx].Msg)
+				printer.Success(res[idx].Summary())
 				break
-			} else if current != verifyInfo.retryCount {
+			}
+			delay, retried := retry.Next(current+1, start)
+			if retried && caseCtx.Err() == nil {
 				if current == 0 {
 					printer.UpdateText(fmt.Sprintf("failed to verify %v, will continue retry:", caseName(v)))
 				} else {
-					printer.UpdateText(fmt.Sprintf("failed to verify %v, retry [%d/%d]", caseName(v), current, verifyInfo.retryCount))
+					printer.UpdateText(fmt.Sprintf("failed to verify %v, retry [%s]", caseName(v), retry.Progress(current)))
 				}
-				time.Sleep(verifyInfo.interval)
-			} else {
-				res[idx].Msg = fmt.Sprintf("failed to verify %v, retried %d time(s):", caseName(v), current)
-				res[idx].Err = e
-				res[idx].Skip = false
-				printer.UpdateText(fmt.Sprintf("failed to verify %v, retry [%d/%d]", caseName(v), current, verifyInfo.retryCount))
-				printer.Warning(res[idx].Msg)
-				printer.Fail(res[idx].Err.Error())
-				if verifyInfo.failFast {
-					return
+				if util.SleepContext(caseCtx, delay) {
+					continue
 				}
 			}
+			res[idx].Msg = config.CaseFailureMessage(caseCtx, "verify", caseName(v), current)
+			res[idx].Err = e
+			res[idx].Skip = false
+			res[idx].Duration = time.Since(start)
+			printer.UpdateText(fmt.Sprintf("failed to verify %v, retry [%s]", caseName(v), retry.Progress(current)))
+			printer.FailCase(res[idx])
+			cancelCase()
+			if verifyInfo.failFast {
+				return
+			}
+			break
 		}
+		cancelCase()
 	}
 
 	return nil
@@ -263,11 +322,7 @@ func DoVerifyAccordingConfig() error {
 		return config.GlobalConfig.Error
 	}
 	e2eConfig := config.GlobalConfig.E2EConfig
-	retryCount := e2eConfig.Verify.RetryStrategy.Count
-	if retryCount <= 0 {
-		retryCount = 0
-	}
-	interval, err := parseInterval(e2eConfig.Verify.RetryStrategy.Interval)
+	retry, err := e2eConfig.Verify.RetryStrategy.Parse("verify.retry")
 	if err != nil {
 		return err
 	}
@@ -276,8 +331,7 @@ func DoVerifyAccordingConfig() error {
 
 	VerifyInfo := verifyInfo{
 		caseNumber,
-		retryCount,
-		interval,
+		retry,
 		failFast,
 	}
 
@@ -291,27 +345,3 @@ func DoVerifyAccordingConfig() error {
 	printer = output.NewPrinter(util.BatchMode)
 	return verifyCasesSerially(&e2eConfig.Verify, &VerifyInfo)
 }
-
-// TODO remove this in 2.0.0
-func parseInterval(retryInterval any) (time.Duration, error) {
-	var interval time.Duration
-	var err error
-	switch itv := retryInterval.(type) {
-	case int:
-		logger.Log.Warnf(`configuring verify.retry.interval with number is deprecated
-and will be removed in future version, please use Duration style instead, such as 10s, 1m.`)
-		interval = time.Duration(itv) * time.Millisecond
-	case string:
-		if interval, err = time.ParseDuration(itv); err != nil {
-			return 0, err
-		}
-	case nil:
-		interval = 0
-	default:
-		return 0, fmt.Errorf("failed to parse verify.retry.interval: %v", retryInterval)
-	}
-	if interval < 0 {
-		interval = 1 * time.Second
-	}
-	return interval, nil
-}
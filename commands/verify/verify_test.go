@@ -6,7 +6,7 @@
 // not use this file except in compliance with the License.
 // You may obtain a copy of the License at
 //
-//     http://www.apache.org/licenses/LICENSE-2.0
+//	http://www.apache.org/licenses/LICENSE-2.0
 //
 // Unless required by applicable law or agreed to in writing,
 // software distributed under the License is distributed on an
@@ -14,63 +14,40 @@
 // KIND, either express or implied.  See the License for the
 // specific language governing permissions and limitations
 // under the License.
-//
 package verify
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/util"
 )
 
-func Test_parseInterval(t *testing.T) {
-	type args struct {
-		retryInterval any
+func TestVerifySingleCase_QueryTimeout(t *testing.T) {
+	workDir := util.WorkDir
+	defer func() { util.WorkDir = workDir }()
+	util.WorkDir = t.TempDir()
+
+	expectedFile := filepath.Join(t.TempDir(), "expected.yaml")
+	if err := os.WriteFile(expectedFile, []byte("status: ok\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := verifySingleCase(ctx, expectedFile, "", "echo started >&2 && sleep 10")
+	if err == nil {
+		t.Fatal("verifySingleCase() should fail if the query times out")
 	}
-	tests := []struct {
-		name    string
-		args    args
-		want    time.Duration
-		wantErr bool
-	}{
-		{
-			name:    "Backward compatibility, should parse numeric value",
-			args:    args{retryInterval: 1000},
-			want:    time.Second,
-			wantErr: false,
-		},
-		{
-			name:    "Should parse duration like 10s",
-			args:    args{retryInterval: "10s"},
-			want:    10 * time.Second,
-			wantErr: false,
-		},
-		{
-			name:    "Should have default value if < 0",
-			args:    args{retryInterval: "-10s"},
-			want:    1 * time.Second,
-			wantErr: false,
-		},
-		{
-			name:    "Should fail in other cases",
-			args:    args{retryInterval: "abcdef"},
-			wantErr: true,
-		}, {
-			name:    "Should parse interval without setting value",
-			args:    args{},
-			want:    0,
-			wantErr: false,
-		},
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("verifySingleCase() returned after %s, the query should be killed", elapsed)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := parseInterval(tt.args.retryInterval)
-			if (err != nil) != tt.wantErr {
-				t.Errorf("parseInterval() error = %v, wantErr %v", err, tt.wantErr)
-				return
-			}
-			if got != tt.want {
-				t.Errorf("parseInterval() got = %v, want %v", got, tt.want)
-			}
-		})
+	if !strings.Contains(err.Error(), context.DeadlineExceeded.Error()) {
+		t.Errorf("verifySingleCase() error = %v, should contain the reason of the failure", err)
 	}
 }
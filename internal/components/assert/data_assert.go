@@ -1,8 +1,10 @@
 package assert
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/pkg/errors"
@@ -69,6 +71,11 @@ func LoadTracesData(path string) (*entity.TraceData, error) {
 
 // AssertMetrics assert metrics message
 func MetricsAssert(expectedPath, query string) error {
+	return MetricsAssertContext(context.Background(), expectedPath, query)
+}
+
+// MetricsAssertContext asserts metrics message, the query is canceled when the context is done.
+func MetricsAssertContext(ctx context.Context, expectedPath, query string) error {
 	var expected []*prom2json.Family
 	var actual []*prom2json.Family
 	var err error
@@ -83,7 +90,7 @@ func MetricsAssert(expectedPath, query string) error {
 		return errors.Wrap(err, "failed unmarshal expected metrics")
 	}
 
-	if actual, err = LoadMetricsData(query); err != nil {
+	if actual, err = LoadMetricsDataContext(ctx, query); err != nil {
 		return err
 	}
 
@@ -102,11 +109,16 @@ type result struct {
 
 // LoadMetricsData load metrics from request url.
 func LoadMetricsData(url string) ([]*prom2json.Family, error) {
+	return LoadMetricsDataContext(context.Background(), url)
+}
+
+// LoadMetricsDataContext loads metrics from request url, the request is canceled when the context is done.
+func LoadMetricsDataContext(ctx context.Context, url string) ([]*prom2json.Family, error) {
 	url = os.ExpandEnv(url)
 	mfChan := make(chan *dto.MetricFamily, 1024)
 	retCh := make(chan result, 1)
 	go func() {
-		err := prom2json.FetchMetricFamilies(url, mfChan, nil)
+		err := prom2json.FetchMetricFamilies(url, mfChan, contextTransport{ctx: ctx})
 		retCh <- result{
 			err: err,
 			msg: "fetch metric data",
@@ -124,3 +136,12 @@ func LoadMetricsData(url string) ([]*prom2json.Family, error) {
 	}
 	return result, nil
 }
+
+// contextTransport sends the requests with the context, so they are canceled when the context is done.
+type contextTransport struct {
+	ctx context.Context
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return http.DefaultTransport.RoundTrip(req.WithContext(t.ctx))
+}
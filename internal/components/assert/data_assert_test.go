@@ -1,9 +1,13 @@
 package assert
 
 import (
+	"context"
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/prometheus/prom2json"
 	"github.com/stretchr/testify/assert"
@@ -36,3 +40,17 @@ func TestUmmarslMetrics(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, 2, len(metrics))
 }
+
+func TestLoadMetricsDataContext(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := LoadMetricsDataContext(ctx, server.URL)
+	assert.Error(t, err)
+}
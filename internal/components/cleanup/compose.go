@@ -22,26 +22,18 @@ import (
 	"github.com/apache/skywalking-infra-e2e/internal/components/setup"
 	"github.com/apache/skywalking-infra-e2e/internal/config"
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
-
-	"github.com/testcontainers/testcontainers-go"
-
-	"fmt"
 )
 
 func ComposeCleanUp(conf *config.E2EConfig) error {
-	composeFilePath := conf.Setup.GetFile()
 	logger.Log.Infof("deleting docker compose cluster...\n")
 
-	if composeFilePath == "" {
-		return fmt.Errorf("no compose config file was provided")
+	project, err := setup.LoadComposeProject(&conf.Setup)
+	if err != nil {
+		return err
 	}
-	composeFilePaths := []string{composeFilePath}
-	identifier := setup.GetIdentity()
-	compose := testcontainers.NewLocalDockerCompose(composeFilePaths, identifier)
-	down := compose.Down()
-	if down.Error != nil {
-		return down.Error
+	driver, err := setup.NewComposeDriver(conf.Setup.Compose.Driver)
+	if err != nil {
+		return err
 	}
-
-	return nil
+	return driver.Down(project)
 }
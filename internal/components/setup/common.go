@@ -32,9 +32,28 @@ import (
 
 var (
 	logFollower *util.ResourceLogFollower
+	stepResults []*StepResult
 )
 
-func RunStepsAndWait(steps []config.Step, waitTimeout time.Duration, k8sCluster *util.K8sClusterInfo) error {
+// StepResult is the result of a setup step.
+type StepResult struct {
+	Name     string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// StepResults returns the results of the setup steps which have been executed.
+func StepResults() []*StepResult {
+	return stepResults
+}
+
+// ResetStepResults clears the results of the setup steps, it's called before each setup.
+func ResetStepResults() {
+	stepResults = nil
+}
+
+func RunStepsAndWait(steps []config.Step, waitTimeout time.Duration, env *StepEnv) error {
 	logger.Log.Debugf("wait timeout is %v", waitTimeout.String())
 
 	// record time now
@@ -43,30 +62,13 @@ func RunStepsAndWait(steps []config.Step, waitTimeout time.Duration, k8sCluster
 	for _, step := range steps {
 		logger.Log.Infof("processing setup step [%s]", step.Name)
 
-		if step.Path != "" && step.Command == "" {
-			if k8sCluster == nil {
-				return fmt.Errorf("not support path")
-			}
-			manifest := config.Manifest{
-				Path:  step.Path,
-				Waits: step.Waits,
-			}
-			err := createManifestAndWait(k8sCluster, manifest, waitTimeout)
-			if err != nil {
-				return err
-			}
-		} else if step.Command != "" && step.Path == "" {
-			command := config.Run{
-				Command: step.Command,
-				Waits:   step.Waits,
-			}
-
-			err := RunCommandsAndWait(command, waitTimeout, k8sCluster)
-			if err != nil {
-				return err
-			}
-		} else {
-			return fmt.Errorf("step parameter error, one Path or one Command should be specified, but got %+v", step)
+		err := step.ExpandEnv()
+		if err == nil {
+			err = runStep(step, waitTimeout, env)
+		}
+		stepResults = append(stepResults, &StepResult{Name: step.Name, Start: timeNow, Duration: time.Since(timeNow), Err: err})
+		if err != nil {
+			return err
 		}
 
 		waitTimeout = NewTimeout(timeNow, waitTimeout)
@@ -79,12 +81,32 @@ func RunStepsAndWait(steps []config.Step, waitTimeout time.Duration, k8sCluster
 	return nil
 }
 
+func runStep(step config.Step, waitTimeout time.Duration, env *StepEnv) error {
+	if step.Path != "" && step.Command == "" {
+		if env.Cluster == nil {
+			return fmt.Errorf("not support path")
+		}
+		manifest := config.Manifest{
+			Path:  step.Path,
+			Waits: step.Waits,
+		}
+		return createManifestAndWait(env, manifest, waitTimeout)
+	} else if step.Command != "" && step.Path == "" {
+		command := config.Run{
+			Command: step.Command,
+			Waits:   step.Waits,
+		}
+		return RunCommandsAndWait(command, waitTimeout, env)
+	}
+	return fmt.Errorf("step parameter error, one Path or one Command should be specified, but got %+v", step)
+}
+
 // createManifestAndWait creates manifests in k8s cluster and concurrent waits according to the manifests' wait conditions.
-func createManifestAndWait(c *util.K8sClusterInfo, manifest config.Manifest, timeout time.Duration) error {
+func createManifestAndWait(env *StepEnv, manifest config.Manifest, timeout time.Duration) error {
 	waitSet := util.NewWaitSet(timeout)
 
 	waits := manifest.Waits
-	err := createByManifest(c, manifest)
+	err := createByManifest(env.Cluster, manifest)
 	if err != nil {
 		return err
 	}
@@ -97,9 +119,14 @@ func createManifestAndWait(c *util.K8sClusterInfo, manifest config.Manifest, tim
 
 	for idx := range waits {
 		wait := waits[idx]
+		if wait.Type != "" {
+			waitSet.WaitGroup.Add(1)
+			go concurrentlyWaitFor(env, &wait, waitSet)
+			continue
+		}
 		logger.Log.Infof("waiting for %+v", wait)
 
-		options, err := getWaitOptions(c, &wait)
+		options, err := getWaitOptions(env.Cluster, &wait)
 		if err != nil {
 			return err
 		}
@@ -127,7 +154,7 @@ func createManifestAndWait(c *util.K8sClusterInfo, manifest config.Manifest, tim
 }
 
 // RunCommandsAndWait Concurrently run commands and wait for conditions.
-func RunCommandsAndWait(run config.Run, timeout time.Duration, cluster *util.K8sClusterInfo) error {
+func RunCommandsAndWait(run config.Run, timeout time.Duration, env *StepEnv) error {
 	waitSet := util.NewWaitSet(timeout)
 
 	commands := run.Command
@@ -136,7 +163,7 @@ func RunCommandsAndWait(run config.Run, timeout time.Duration, cluster *util.K8s
 	}
 
 	waitSet.WaitGroup.Add(1)
-	go executeCommandsAndWait(commands, run.Waits, waitSet, cluster)
+	go executeCommandsAndWait(commands, run.Waits, waitSet, env)
 
 	go func() {
 		waitSet.WaitGroup.Wait()
@@ -156,7 +183,7 @@ func RunCommandsAndWait(run config.Run, timeout time.Duration, cluster *util.K8s
 	return nil
 }
 
-func executeCommandsAndWait(commands string, waits []config.Wait, waitSet *util.WaitSet, cluster *util.K8sClusterInfo) {
+func executeCommandsAndWait(commands string, waits []config.Wait, waitSet *util.WaitSet, env *StepEnv) {
 	defer waitSet.WaitGroup.Done()
 
 	// executes commands
@@ -171,12 +198,24 @@ func executeCommandsAndWait(commands string, waits []config.Wait, waitSet *util.
 	// waits for conditions meet
 	for idx := range waits {
 		wait := waits[idx]
+		if wait.Type != "" {
+			if err := waitFor(env, &wait, waitSet.Timeout); err != nil {
+				waitSet.ErrChan <- fmt.Errorf("commands: [%s] waits error: %s", commands, err)
+				return
+			}
+			continue
+		}
 		logger.Log.Infof("waiting for %+v", wait)
 
-		options, err := getWaitOptions(cluster, &wait)
+		if env.Cluster == nil {
+			waitSet.ErrChan <- fmt.Errorf("commands: [%s] the wait without type is only supported in kind: %+v", commands, wait)
+			return
+		}
+		options, err := getWaitOptions(env.Cluster, &wait)
 		if err != nil {
 			err = fmt.Errorf("commands: [%s] get wait options error: %s", commands, err)
 			waitSet.ErrChan <- err
+			return
 		}
 
 		err = options.RunWait()
@@ -189,6 +228,18 @@ func executeCommandsAndWait(commands string, waits []config.Wait, waitSet *util.
 	}
 }
 
+// concurrentlyWaitFor waits for the environment-agnostic condition in the wait set.
+func concurrentlyWaitFor(env *StepEnv, wait *config.Wait, waitSet *util.WaitSet) {
+	defer waitSet.WaitGroup.Done()
+
+	if err := waitFor(env, wait, waitSet.Timeout); err != nil {
+		select {
+		case waitSet.ErrChan <- err:
+		default:
+		}
+	}
+}
+
 // NewTimeout calculates new timeout since timeBefore.
 func NewTimeout(timeBefore time.Time, timeout time.Duration) time.Duration {
 	elapsed := time.Since(timeBefore)
@@ -199,7 +250,10 @@ func NewTimeout(timeBefore time.Time, timeout time.Duration) time.Duration {
 func GetIdentity() string {
 	runID := os.Getenv("CI_JOB_ID")
 	if runID == "" {
-		return "hera-e2e"
+		runID = "hera-e2e"
+	}
+	if util.Variant != "" {
+		runID = fmt.Sprintf("%s-%s", runID, util.Variant)
 	}
 	return runID
 }
@@ -26,6 +26,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/docker/go-connections/nat"
 	"github.com/testcontainers/testcontainers-go/wait"
@@ -38,8 +40,6 @@ import (
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/client"
 	"github.com/docker/docker/pkg/stdcopy"
-
-	"github.com/testcontainers/testcontainers-go"
 )
 
 const (
@@ -52,14 +52,14 @@ const (
 )
 
 var (
-	containerNamePattern = regexp.MustCompile(`.*_(?P<containerNum>\d+)$`)
+	containerNamePattern = regexp.MustCompile(`^(?P<serviceName>.*)_(?P<containerNum>\d+)$`)
 )
 
 // ComposeSetup sets up environment according to e2e.yaml.
 func ComposeSetup(e2eConfig *config.E2EConfig) error {
-	composeConfigPath := e2eConfig.Setup.GetFile()
-	if composeConfigPath == "" {
-		return fmt.Errorf("no compose config file was provided")
+	project, err := LoadComposeProject(&e2eConfig.Setup)
+	if err != nil {
+		return err
 	}
 
 	// build docker client
@@ -69,53 +69,48 @@ func ComposeSetup(e2eConfig *config.E2EConfig) error {
 	}
 
 	// setup docker compose
-	composeFilePaths := []string{
-		composeConfigPath,
+	driver, err := NewComposeDriver(e2eConfig.Setup.Compose.Driver)
+	if err != nil {
+		return err
 	}
-	identifier := GetIdentity()
-	compose := testcontainers.NewLocalDockerCompose(composeFilePaths, identifier)
 
 	// bind wait port
-	services, err := buildComposeServices(e2eConfig, compose)
+	services, err := buildComposeServices(e2eConfig, project)
 	if err != nil {
 		return fmt.Errorf("bind wait ports error: %v", err)
 	}
 
-	// build command
-	cmd := make([]string, 0)
+	// build options
+	options := make([]string, 0)
 	if e2eConfig.Setup.InitSystemEnvironment != "" {
 		profilePath := util.ResolveAbs(e2eConfig.Setup.InitSystemEnvironment)
-		cmd = append(cmd, "--env-file", profilePath)
-		util.ExportEnvVars(profilePath)
+		options = append(options, "--env-file", profilePath)
 	}
-	cmd = append(cmd, "up", "-d")
 
 	// Listen container create
 	listener := NewComposeContainerListener(context.Background(), cli, services)
 	defer listener.Stop()
 	err = listener.Listen(func(container *ComposeContainer) {
-		if err = exposeComposeLog(cli, container.Service, container.ID, logFollower); err == nil {
-			container.Service.beenFollowLog = true
-		}
+		// the log is followed again after the services are up if it fails here
+		_ = container.Service.followLog(cli, container.ID, container.Number)
 	})
 	if err != nil {
 		return err
 	}
 
 	// setup
-	execError := compose.WithCommand(cmd).Invoke()
-	if execError.Error != nil {
-		return execError.Error
+	if err := driver.Up(project, options...); err != nil {
+		return err
 	}
 
 	// find exported port and build env
-	err = exposeComposeService(services, cli, identifier, e2eConfig)
+	err = exposeComposeService(services, cli, project.Name)
 	if err != nil {
 		return err
 	}
 
 	// run steps
-	err = RunStepsAndWait(e2eConfig.Setup.Steps, e2eConfig.Setup.GetTimeout(), nil)
+	err = RunStepsAndWait(e2eConfig.Setup.Steps, e2eConfig.Setup.GetTimeout(), &StepEnv{Docker: cli, Project: project})
 	if err != nil {
 		logger.Log.Errorf("execute steps error: %v", err)
 		return err
@@ -127,31 +122,56 @@ func ComposeSetup(e2eConfig *config.E2EConfig) error {
 type ComposeService struct {
 	Name           string
 	waitStrategies []*hostPortCachedStrategy
-	beenFollowLog  bool
+	waits          []config.ComposeWait
+	timeout        time.Duration
+	replicas       int
+
+	logLock      sync.Mutex
+	followedLogs map[string]bool
 }
 
-func exposeComposeService(services []*ComposeService, cli *client.Client,
-	identity string, e2eConfig *config.E2EConfig) error {
+func exposeComposeService(services []*ComposeService, cli *client.Client, identity string) error {
 	dockerProvider := &DockerProvider{client: cli}
 
 	// find exported port and build env
 	for _, service := range services {
+		containers, err := service.FindContainers(cli, identity)
+		if err != nil {
+			// the service without ports and conditions to wait may have exited, such as an initialization job
+			if len(service.waitStrategies) == 0 && len(service.waits) == 0 {
+				logger.Log.Warn(err)
+				continue
+			}
+			return err
+		}
+		if err := exposeComposeReplicas(dockerProvider, service, containers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exposeComposeReplicas waits for every replica of the service to be ready, exports its ports and follows its log.
+func exposeComposeReplicas(dockerProvider *DockerProvider, service *ComposeService, containers []*types.Container) error {
+	// the timeout of the conditions covers all the replicas of the service
+	ctx, cancel := context.WithTimeout(context.Background(), service.timeout)
+	defer cancel()
+
+	for idx, container := range containers {
+		number := idx + 1
 		// expose port
-		if err := exposeComposePort(dockerProvider, service, cli, identity, e2eConfig); err != nil {
+		if err := exposeComposePort(dockerProvider, service, container, number); err != nil {
+			return err
+		}
+
+		// wait for the conditions of the service
+		if err := waitComposeConditions(ctx, dockerProvider, service, container); err != nil {
 			return err
 		}
 
 		// if service log not follow, expose log
-		if !service.beenFollowLog {
-			c, err := service.FindContainer(cli, identity)
-			if err != nil {
-				logger.Log.Warn(err)
-				continue
-			}
-			if err := exposeComposeLog(dockerProvider.client, service, c.ID, logFollower); err != nil {
-				return err
-			}
-			service.beenFollowLog = true
+		if err := service.followLog(dockerProvider.client, container.ID, number); err != nil {
+			return err
 		}
 	}
 	return nil
@@ -162,26 +182,68 @@ func (c *ComposeService) FindContainer(cli *client.Client, identity string) (*ty
 	return findContainer(cli, identity, serviceName, num)
 }
 
-func exposeComposePort(dockerProvider *DockerProvider, service *ComposeService, cli *client.Client, identity string,
-	e2eConfig *config.E2EConfig) error {
-	if len(service.waitStrategies) == 0 {
+// FindContainers finds the containers of all the replicas of the service, ordered by their numbers.
+func (c *ComposeService) FindContainers(cli *client.Client, identity string) ([]*types.Container, error) {
+	switch c.replicas {
+	case 0:
+		return nil, nil
+	case 1:
+		container, err := c.FindContainer(cli, identity)
+		if err != nil {
+			return nil, err
+		}
+		return []*types.Container{container}, nil
+	}
+
+	containers := make([]*types.Container, 0, c.replicas)
+	for number := 1; number <= c.replicas; number++ {
+		container, err := findContainer(cli, identity, c.Name, number)
+		if err != nil {
+			return nil, err
+		}
+		containers = append(containers, container)
+	}
+	return containers, nil
+}
+
+// followLog follows the log of the container of the service if it's not followed yet,
+// the log of each replica is written to its own file when the service is scaled.
+func (c *ComposeService) followLog(cli *client.Client, containerID string, number int) error {
+	c.logLock.Lock()
+	defer c.logLock.Unlock()
+	if c.followedLogs[containerID] {
 		return nil
 	}
 
-	// get real ip address for access and export to env
-	host, err := dockerProvider.daemonHost(context.Background())
-	if err != nil {
+	name := fmt.Sprintf("%s/std.log", c.Name)
+	if c.replicas > 1 {
+		name = fmt.Sprintf("%s/%d/std.log", c.Name, number)
+	}
+	if err := exposeComposeLog(cli, name, containerID, logFollower); err != nil {
 		return err
 	}
+	c.followedLogs[containerID] = true
+	return nil
+}
 
-	container, err := service.FindContainer(cli, identity)
+// exposeComposePort waits for the ports of the container to be ready, and exports them to env,
+// the ports of the first replica are also exported without the replica number for compatibility.
+func exposeComposePort(dockerProvider *DockerProvider, service *ComposeService, container *types.Container, number int) error {
+	if len(service.waitStrategies) == 0 {
+		return nil
+	}
+
+	// get real ip address for access and export to env
+	host, err := dockerProvider.daemonHost(context.Background())
 	if err != nil {
 		return err
 	}
 
 	// format: <service_name>_host
-	if err := exportComposeEnv(fmt.Sprintf("%s_host", service.Name), host, service.Name); err != nil {
-		return err
+	if number == 1 {
+		if err := exportComposeEnv(fmt.Sprintf("%s_host", service.Name), host, service.Name); err != nil {
+			return err
+		}
 	}
 
 	for inx := range service.waitStrategies {
@@ -190,14 +252,22 @@ func exposeComposePort(dockerProvider *DockerProvider, service *ComposeService,
 				continue
 			}
 
-			if err := waitPortUntilReady(e2eConfig, container, dockerProvider, service.waitStrategies[inx].expectPort); err != nil {
+			if err := waitPortUntilReady(service.timeout, container, dockerProvider, service.waitStrategies[inx].expectPort); err != nil {
 				return err
 			}
 
 			// expose env config to env
-			// format: <service_name>_<port>
+			// format: <service_name>_<port> and <service_name>_<number>_<port>
+			if number == 1 {
+				if err := exportComposeEnv(
+					fmt.Sprintf("%s_%d", service.Name, containerPort.PrivatePort),
+					fmt.Sprintf("%d", containerPort.PublicPort),
+					service.Name); err != nil {
+					return err
+				}
+			}
 			if err := exportComposeEnv(
-				fmt.Sprintf("%s_%d", service.Name, containerPort.PrivatePort),
+				fmt.Sprintf("%s_%d_%d", service.Name, number, containerPort.PrivatePort),
 				fmt.Sprintf("%d", containerPort.PublicPort),
 				service.Name); err != nil {
 				return err
@@ -210,7 +280,7 @@ func exposeComposePort(dockerProvider *DockerProvider, service *ComposeService,
 }
 
 // export container log to local path
-func exposeComposeLog(cli *client.Client, service *ComposeService, containerID string, logFollower *util.ResourceLogFollower) error {
+func exposeComposeLog(cli *client.Client, name, containerID string, logFollower *util.ResourceLogFollower) error {
 	logs, err := cli.ContainerLogs(logFollower.Ctx, containerID, types.ContainerLogsOptions{
 		ShowStdout: true,
 		ShowStderr: true,
@@ -220,7 +290,7 @@ func exposeComposeLog(cli *client.Client, service *ComposeService, containerID s
 	if err != nil {
 		return err
 	}
-	writer, err := logFollower.BuildLogWriter(fmt.Sprintf("%s/std.log", service.Name))
+	writer, err := logFollower.BuildLogWriter(name)
 	if err != nil {
 		return err
 	}
@@ -228,7 +298,7 @@ func exposeComposeLog(cli *client.Client, service *ComposeService, containerID s
 	go func() {
 		defer writer.Close()
 		if _, err := stdcopy.StdCopy(writer, writer, logs); err != nil && !errors.Is(err, context.Canceled) {
-			logger.Log.Warnf("write %s std log error: %v", service.Name, err)
+			logger.Log.Warnf("write %s error: %v", name, err)
 		}
 	}()
 	return nil
@@ -243,13 +313,41 @@ func exportComposeEnv(key, value, service string) error {
 	return nil
 }
 
-func buildComposeServices(e2eConfig *config.E2EConfig, compose *testcontainers.LocalDockerCompose) ([]*ComposeService, error) {
-	waitTimeout := e2eConfig.Setup.GetTimeout()
+func buildComposeServices(e2eConfig *config.E2EConfig, project *ComposeProject) ([]*ComposeService, error) {
+	serviceConfigs := make(map[string]*config.ComposeService)
+	for idx := range e2eConfig.Setup.Compose.Services {
+		s := &e2eConfig.Setup.Compose.Services[idx]
+		if _, ok := project.Services[s.Name]; !ok {
+			return nil, fmt.Errorf("the service %s in setup.compose.services is not found in the compose files", s.Name)
+		}
+		serviceConfigs[s.Name] = s
+	}
+
 	services := make([]*ComposeService, 0)
-	for service, content := range compose.Services {
+	for service, content := range project.Services {
 		serviceConfig := content.(map[any]any)
 		ports := serviceConfig["ports"]
-		serviceContext := &ComposeService{Name: service}
+		replicas, err := getReplicas(serviceConfig)
+		if err != nil {
+			return nil, fmt.Errorf("the replicas of service %s error: %v", service, err)
+		}
+		serviceContext := &ComposeService{
+			Name:         service,
+			timeout:      e2eConfig.Setup.GetTimeout(),
+			replicas:     replicas,
+			followedLogs: make(map[string]bool),
+		}
+		if c := serviceConfigs[service]; c != nil {
+			timeout, err := c.GetTimeout()
+			if err != nil {
+				return nil, err
+			}
+			if timeout > 0 {
+				serviceContext.timeout = timeout
+			}
+			serviceContext.waits = c.Waits
+		}
+		waitTimeout := serviceContext.timeout
 		services = append(services, serviceContext)
 		if ports == nil {
 			continue
@@ -274,6 +372,27 @@ func buildComposeServices(e2eConfig *config.E2EConfig, compose *testcontainers.L
 	return services, nil
 }
 
+// getReplicas returns the replicas of the service configured by `deploy.replicas` or `scale`, it's 1 by default.
+func getReplicas(serviceConfig map[any]any) (int, error) {
+	replicas := serviceConfig["scale"]
+	if deploy, ok := serviceConfig["deploy"].(map[any]any); ok && deploy["replicas"] != nil {
+		replicas = deploy["replicas"]
+	}
+	switch r := replicas.(type) {
+	case nil:
+		return 1, nil
+	case int:
+		return r, nil
+	case string:
+		expanded, err := util.ExpandEnv(r)
+		if err != nil {
+			return 0, err
+		}
+		return strconv.Atoi(expanded)
+	}
+	return 0, fmt.Errorf("unknown replicas: %v", replicas)
+}
+
 func getExpectPort(portConfig any) (int, error) {
 	switch conf := portConfig.(type) {
 	case int:
@@ -304,6 +423,14 @@ func findContainer(c *client.Client, projectName, serviceName string, number int
 	if len(containers) == 0 {
 		return nil, fmt.Errorf("could not found container: %s(docker-compose v1) or %s(docker-compose v2)", nameV1, nameV2)
 	}
+	// the name filter matches the names containing it, such as {service}-1 matches {service}-10
+	for idx := range containers {
+		for _, name := range containers[idx].Names {
+			if name == "/"+nameV1 || name == "/"+nameV2 {
+				return &containers[idx], nil
+			}
+		}
+	}
 	return &containers[0], nil
 }
 
@@ -312,12 +439,11 @@ func getInstanceName(serviceName string) (service string, number int) {
 	if len(matches) == 0 {
 		return serviceName, 1
 	}
-	numberStr := matches[0]
-	number, err := strconv.Atoi(numberStr)
+	number, err := strconv.Atoi(matches[containerNamePattern.SubexpIndex("containerNum")])
 	if err != nil {
 		return serviceName, 1
 	}
-	return serviceName, number
+	return matches[containerNamePattern.SubexpIndex("serviceName")], number
 }
 
 // hostPortCachedStrategy cached original target
@@ -332,9 +458,8 @@ func (hp *hostPortCachedStrategy) WaitUntilReady(ctx context.Context, target wai
 	return hp.HostPortStrategy.WaitUntilReady(ctx, target)
 }
 
-func waitPortUntilReady(e2eConfig *config.E2EConfig, container *types.Container, dockerProvider *DockerProvider, expectPort int) error {
+func waitPortUntilReady(waitTimeout time.Duration, container *types.Container, dockerProvider *DockerProvider, expectPort int) error {
 	// wait port
-	waitTimeout := e2eConfig.Setup.GetTimeout()
 	waitPort := nat.Port(fmt.Sprintf("%d/tcp", expectPort))
 	target := &DockerContainer{
 		ID:         container.ID,
@@ -0,0 +1,186 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package setup
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+// ComposeProject is the compose project to set up, which consists of the compose files, the profiles and the project name.
+type ComposeProject struct {
+	Files    []string
+	Profiles []string
+	Name     string
+	// Services are the definitions of the enabled services in the compose files, keyed by the service names.
+	Services map[string]any
+}
+
+// LoadComposeProject loads the compose project configured in the setup, the project name is suffixed by the matrix variant.
+func LoadComposeProject(s *config.Setup) (*ComposeProject, error) {
+	files := s.GetComposeFiles()
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no compose config file was provided")
+	}
+	name := GetIdentity()
+	if s.Compose.ProjectName != "" {
+		name = s.Compose.ProjectName
+		if util.Variant != "" {
+			name = fmt.Sprintf("%s-%s", name, util.Variant)
+		}
+	}
+	return NewComposeProject(files, s.Compose.Profiles, name)
+}
+
+// NewComposeProject loads the services from the compose files, the later files override the former ones,
+// and the services which are not in any of the profiles are dropped.
+func NewComposeProject(files, profiles []string, name string) (*ComposeProject, error) {
+	project := &ComposeProject{
+		Files:    files,
+		Profiles: profiles,
+		Name:     strings.ToLower(name),
+		Services: make(map[string]any),
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("read compose file %s error: %v", file, err)
+		}
+		compose := struct {
+			Services map[string]map[any]any `yaml:"services"`
+		}{}
+		if err := yaml.Unmarshal(data, &compose); err != nil {
+			return nil, fmt.Errorf("unmarshal compose file %s error: %v", file, err)
+		}
+		for name, service := range compose.Services {
+			existing, ok := project.Services[name].(map[any]any)
+			if !ok {
+				existing = make(map[any]any)
+				project.Services[name] = existing
+			}
+			for key, value := range service {
+				existing[key] = value
+			}
+		}
+	}
+	for name, service := range project.Services {
+		if !project.enabled(service.(map[any]any)) {
+			delete(project.Services, name)
+		}
+	}
+	return project, nil
+}
+
+// enabled returns whether the service is enabled, the services without profiles are always enabled.
+func (p *ComposeProject) enabled(service map[any]any) bool {
+	profiles, ok := service["profiles"].([]any)
+	if !ok || len(profiles) == 0 {
+		return true
+	}
+	for _, profile := range profiles {
+		for _, enabled := range p.Profiles {
+			if fmt.Sprint(profile) == enabled {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ComposeDriver drives the compose project.
+type ComposeDriver interface {
+	// Up creates and starts the services, the options are placed before the `up` sub command, such as `--env-file`.
+	Up(project *ComposeProject, options ...string) error
+	// Down stops and removes the services.
+	Down(project *ComposeProject) error
+}
+
+// NewComposeDriver creates the compose driver by its name in `setup.compose.driver`, it's detected if it's empty or auto.
+func NewComposeDriver(name string) (ComposeDriver, error) {
+	switch name {
+	case "", constant.ComposeDriverAuto:
+		return detectComposeDriver()
+	case constant.ComposeDriverStandalone:
+		return &commandComposeDriver{command: []string{constant.ComposeCommand}}, nil
+	case constant.ComposeDriverPlugin:
+		return &commandComposeDriver{command: []string{"docker", "compose"}}, nil
+	}
+	return nil, fmt.Errorf("unknown compose driver: %s, should be one of %s, %s, %s", name,
+		constant.ComposeDriverAuto, constant.ComposeDriverStandalone, constant.ComposeDriverPlugin)
+}
+
+// detectComposeDriver prefers the standalone docker-compose to keep the behavior of the existing environments,
+// and falls back to the docker compose plugin.
+func detectComposeDriver() (ComposeDriver, error) {
+	if _, err := exec.LookPath(constant.ComposeCommand); err == nil {
+		logger.Log.Infof("using the standalone %s", constant.ComposeCommand)
+		return &commandComposeDriver{command: []string{constant.ComposeCommand}}, nil
+	}
+	if err := exec.Command("docker", "compose", "version").Run(); err == nil {
+		logger.Log.Info("using the docker compose plugin")
+		return &commandComposeDriver{command: []string{"docker", "compose"}}, nil
+	}
+	return nil, fmt.Errorf("neither %s nor the docker compose plugin is found", constant.ComposeCommand)
+}
+
+// commandComposeDriver drives the compose project by the compose command line.
+type commandComposeDriver struct {
+	command []string
+}
+
+func (d *commandComposeDriver) Up(project *ComposeProject, options ...string) error {
+	return d.run(project, append(options, "up", "-d")...)
+}
+
+func (d *commandComposeDriver) Down(project *ComposeProject) error {
+	return d.run(project, "down", "--remove-orphans")
+}
+
+func (d *commandComposeDriver) run(project *ComposeProject, args ...string) error {
+	cmdArgs := append([]string{}, d.command[1:]...)
+	for _, file := range project.Files {
+		cmdArgs = append(cmdArgs, "-f", file)
+	}
+	for _, profile := range project.Profiles {
+		cmdArgs = append(cmdArgs, "--profile", profile)
+	}
+	cmdArgs = append(cmdArgs, "-p", project.Name)
+	cmdArgs = append(cmdArgs, args...)
+
+	cmd := exec.Command(d.command[0], cmdArgs...)
+	if len(project.Files) > 0 {
+		cmd.Dir = filepath.Dir(project.Files[0])
+	}
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	logger.Log.Infof("running: %s", strings.Join(cmd.Args, " "))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %v", strings.Join(cmd.Args, " "), err)
+	}
+	return nil
+}
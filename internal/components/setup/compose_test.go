@@ -0,0 +1,77 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package setup
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestGetInstanceName(t *testing.T) {
+	tests := []struct {
+		name        string
+		wantService string
+		wantNumber  int
+	}{
+		{name: "oap", wantService: "oap", wantNumber: 1},
+		{name: "oap_2", wantService: "oap", wantNumber: 2},
+		{name: "banyandb_data_12", wantService: "banyandb_data", wantNumber: 12},
+		{name: "oap_v2", wantService: "oap_v2", wantNumber: 1},
+	}
+	for _, tt := range tests {
+		service, number := getInstanceName(tt.name)
+		if service != tt.wantService || number != tt.wantNumber {
+			t.Errorf("getInstanceName(%q) = %q, %d, want %q, %d", tt.name, service, number, tt.wantService, tt.wantNumber)
+		}
+	}
+}
+
+func TestGetReplicas(t *testing.T) {
+	t.Setenv("REPLICAS", "4")
+	tests := []struct {
+		name    string
+		service string
+		want    int
+		wantErr bool
+	}{
+		{name: "default", service: "image: oap", want: 1},
+		{name: "deploy replicas", service: "deploy:\n  replicas: 3", want: 3},
+		{name: "scale", service: "scale: 2", want: 2},
+		{name: "deploy replicas over scale", service: "scale: 2\ndeploy:\n  replicas: 3", want: 3},
+		{name: "env", service: "deploy:\n  replicas: ${REPLICAS}", want: 4},
+		{name: "zero", service: "deploy:\n  replicas: 0", want: 0},
+		{name: "invalid", service: "scale: many", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := make(map[any]any)
+			if err := yaml.Unmarshal([]byte(tt.service), &service); err != nil {
+				t.Fatal(err)
+			}
+			got, err := getReplicas(service)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getReplicas() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getReplicas() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package setup
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/docker/docker/api/types"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+)
+
+// waitComposeConditions waits for the container to satisfy all the wait conditions of the service.
+func waitComposeConditions(ctx context.Context, dockerProvider *DockerProvider, service *ComposeService, container *types.Container) error {
+	for idx := range service.waits {
+		w := &service.waits[idx]
+		logger.Log.Infof("waiting for %s of service %s", describeComposeWait(w), service.Name)
+		var err error
+		switch w.Type {
+		case constant.ComposeWaitHealthy:
+			err = waitComposeHealthy(ctx, dockerProvider, container)
+		case constant.ComposeWaitHTTP:
+			err = waitComposeHTTP(ctx, dockerProvider, container, w)
+		case constant.ComposeWaitLog:
+			err = waitComposeLog(ctx, dockerProvider, container, w)
+		case constant.ComposeWaitExec:
+			err = waitComposeExec(ctx, dockerProvider, container, w)
+		default:
+			err = fmt.Errorf("unknown wait type: %s", w.Type)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to wait for %s of service %s: %v", describeComposeWait(w), service.Name, err)
+		}
+	}
+	return nil
+}
+
+func describeComposeWait(w *config.ComposeWait) string {
+	switch w.Type {
+	case constant.ComposeWaitHTTP:
+		return fmt.Sprintf("http port %d%s", w.Port, w.Path)
+	case constant.ComposeWaitLog:
+		return fmt.Sprintf("log %q", w.Regex)
+	case constant.ComposeWaitExec:
+		return fmt.Sprintf("exec %q", w.Command)
+	}
+	return w.Type
+}
+
+func waitComposeHealthy(ctx context.Context, dockerProvider *DockerProvider, container *types.Container) error {
+	return pollCondition(ctx, constant.DefaultWaitInterval, func() error {
+		inspect, err := dockerProvider.client.ContainerInspect(ctx, container.ID)
+		if err != nil {
+			return err
+		}
+		if inspect.State == nil || inspect.State.Health == nil {
+			return fmt.Errorf("the container has no healthcheck")
+		}
+		if inspect.State.Health.Status != types.Healthy {
+			return fmt.Errorf("the health status is %s", inspect.State.Health.Status)
+		}
+		return nil
+	})
+}
+
+func waitComposeHTTP(ctx context.Context, dockerProvider *DockerProvider, container *types.Container, w *config.ComposeWait) error {
+	host, err := dockerProvider.daemonHost(ctx)
+	if err != nil {
+		return err
+	}
+	var publicPort uint16
+	for _, p := range container.Ports {
+		if int(p.PrivatePort) == w.Port && p.PublicPort != 0 {
+			publicPort = p.PublicPort
+			break
+		}
+	}
+	if publicPort == 0 {
+		return fmt.Errorf("the port %d is not published", w.Port)
+	}
+	path, status := w.Path, w.Status
+	if path == "" {
+		path = "/"
+	}
+	if status == 0 {
+		status = http.StatusOK
+	}
+	url := fmt.Sprintf("http://%s:%d%s", host, publicPort, path)
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	return pollCondition(ctx, constant.DefaultWaitInterval, func() error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+		if err != nil {
+			return err
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			return err
+		}
+		_, _ = io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+		if response.StatusCode != status {
+			return fmt.Errorf("the response status of %s is %d, expected %d", url, response.StatusCode, status)
+		}
+		return nil
+	})
+}
+
+func waitComposeLog(ctx context.Context, dockerProvider *DockerProvider, container *types.Container, w *config.ComposeWait) error {
+	pattern, err := regexp.Compile(w.Regex)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := followContainerLog(ctx, dockerProvider.client, container.ID)
+	if err != nil {
+		return err
+	}
+	return matchLog(ctx, []io.ReadCloser{stream}, pattern)
+}
+
+func waitComposeExec(ctx context.Context, dockerProvider *DockerProvider, container *types.Container, w *config.ComposeWait) error {
+	target := &DockerContainer{ID: container.ID, provider: dockerProvider}
+	return pollCondition(ctx, constant.DefaultWaitInterval, func() error {
+		exitCode, err := target.Exec(ctx, []string{"/bin/sh", "-c", w.Command})
+		if err != nil {
+			return err
+		}
+		if exitCode != 0 {
+			return fmt.Errorf("the command exited with %d", exitCode)
+		}
+		return nil
+	})
+}
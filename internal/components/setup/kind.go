@@ -53,6 +53,8 @@ import (
 	kind "sigs.k8s.io/kind/cmd/kind/app"
 	kindcmd "sigs.k8s.io/kind/pkg/cmd"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/apache/skywalking-infra-e2e/internal/config"
 	"github.com/apache/skywalking-infra-e2e/internal/constant"
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
@@ -66,6 +68,11 @@ var (
 	portForwardContext *kindPortForwardContext
 )
 
+// KindClusterNameConfig is the part of the kind config file holding the cluster name.
+type KindClusterNameConfig struct {
+	Name string
+}
+
 type kindPortForwardContext struct {
 	stopChannel             chan struct{}
 	resourceCount           int
@@ -174,12 +181,6 @@ func KindSetup(e2eConfig *config.E2EConfig) error {
 		return nil
 	}
 
-	// export env file
-	if e2eConfig.Setup.InitSystemEnvironment != "" {
-		profilePath := util.ResolveAbs(e2eConfig.Setup.InitSystemEnvironment)
-		util.ExportEnvVars(profilePath)
-	}
-
 	// if there is an existing cluster, don't create a new kind cluster here.
 	if kubeConfigPath == "" {
 		if err := createKindCluster(kindConfigPath, e2eConfig); err != nil {
@@ -196,10 +197,7 @@ func KindSetup(e2eConfig *config.E2EConfig) error {
 
 	// import images
 	if len(e2eConfig.Setup.Kind.ImportImages) > 0 {
-		images := make([]string, 0, len(e2eConfig.Setup.Kind.ImportImages))
-		for _, image := range e2eConfig.Setup.Kind.ImportImages {
-			images = append(images, os.ExpandEnv(image))
-		}
+		images := e2eConfig.Setup.Kind.ImportImages
 		// pull images if this image not exist
 		if err := pullImages(context.Background(), images); err != nil {
 			return err
@@ -233,7 +231,7 @@ func KindSetup(e2eConfig *config.E2EConfig) error {
 	}
 
 	// run steps
-	err = RunStepsAndWait(e2eConfig.Setup.Steps, e2eConfig.Setup.GetTimeout(), cluster)
+	err = RunStepsAndWait(e2eConfig.Setup.Steps, e2eConfig.Setup.GetTimeout(), &StepEnv{Cluster: cluster})
 	if err != nil {
 		logger.Log.Errorf("execute steps error: %v", err)
 		return err
@@ -271,10 +269,15 @@ func KindCleanNotify() {
 
 func createKindCluster(kindConfigPath string, e2eConfig *config.E2EConfig) error {
 	// the config file name of the k8s cluster that kind create
-	kubeConfigPath = constant.K8sClusterConfigFilePath
+	kubeConfigPath = GetKindKubeconfigPath()
+	clusterName, err := GetKindClusterName(kindConfigPath)
+	if err != nil {
+		return err
+	}
 	args := []string{
 		"create", "cluster",
 		"--config", kindConfigPath,
+		"--name", clusterName,
 		"--kubeconfig", kubeConfigPath,
 		"--wait", e2eConfig.Setup.GetTimeout().String(),
 	}
@@ -287,7 +290,7 @@ func createKindCluster(kindConfigPath string, e2eConfig *config.E2EConfig) error
 	logger.Log.Info("create kind cluster succeeded")
 
 	// export kubeconfig path for command line
-	err := os.Setenv("KUBECONFIG", kubeConfigPath)
+	err = os.Setenv("KUBECONFIG", kubeConfigPath)
 	if err != nil {
 		return fmt.Errorf("could not export kubeconfig file path, %v", err)
 	}
@@ -295,6 +298,36 @@ func createKindCluster(kindConfigPath string, e2eConfig *config.E2EConfig) error
 	return nil
 }
 
+// GetKindClusterName returns the name of the kind cluster created from the kind config file,
+// the variant of the matrix combination is added to the name.
+func GetKindClusterName(kindConfigPath string) (string, error) {
+	data, err := os.ReadFile(kindConfigPath)
+	if err != nil {
+		return "", err
+	}
+
+	nameConfig := KindClusterNameConfig{}
+	if err := yaml.Unmarshal(data, &nameConfig); err != nil {
+		return "", err
+	}
+
+	if nameConfig.Name == "" {
+		nameConfig.Name = constant.KindClusterDefaultName
+	}
+	if util.Variant != "" {
+		nameConfig.Name = fmt.Sprintf("%s-%s", nameConfig.Name, util.Variant)
+	}
+	return nameConfig.Name, nil
+}
+
+// GetKindKubeconfigPath returns the path of the kubeconfig file of the kind cluster created by e2e.
+func GetKindKubeconfigPath() string {
+	if util.Variant == "" {
+		return constant.K8sClusterConfigFilePath
+	}
+	return fmt.Sprintf("%s-%s", constant.K8sClusterConfigFilePath, util.Variant)
+}
+
 func getWaitOptions(cluster *util.K8sClusterInfo, wait *config.Wait) (options *ctlwait.WaitOptions, err error) {
 	if strings.Contains(wait.Resource, "/") && wait.LabelSelector != "" {
 		return nil, fmt.Errorf("when passing resource.group/resource.name in Resource, the labelSelector can not be set at the same time")
@@ -550,6 +583,9 @@ func exposeKindService(exports []config.KindExposePort, timeout time.Duration, c
 		resourceCount:           len(exports),
 	}
 	for _, p := range exports {
+		if err := p.ExpandEnv(); err != nil {
+			return err
+		}
 		if err := exposePerKindService(p, waitTimeout, cluster, client, tripperFor, upgrader, forwardContext); err != nil {
 			return err
 		}
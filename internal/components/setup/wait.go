@@ -0,0 +1,292 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package setup
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/stdcopy"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+	"k8s.io/kubectl/pkg/scheme"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+// StepEnv is the environment the setup steps run in,
+// the cluster is set in kind, and the docker client and the compose project are set in compose.
+type StepEnv struct {
+	Cluster *util.K8sClusterInfo
+	Docker  *client.Client
+	Project *ComposeProject
+}
+
+// waitFor waits for the environment-agnostic condition until it's met, the timeout is used if the wait has no timeout.
+func waitFor(env *StepEnv, w *config.Wait, timeout time.Duration) error {
+	interval, err := w.GetInterval()
+	if err != nil {
+		return err
+	}
+	if t, err := w.GetTimeout(); err != nil {
+		return err
+	} else if t > 0 {
+		timeout = t
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	logger.Log.Infof("waiting for %s", describeWait(w))
+	switch w.Type {
+	case constant.WaitHTTP:
+		err = waitHTTP(ctx, w, interval)
+	case constant.WaitTCP:
+		err = pollCondition(ctx, interval, func() error {
+			conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", w.Address)
+			if err != nil {
+				return err
+			}
+			return conn.Close()
+		})
+	case constant.WaitCommand:
+		err = pollCondition(ctx, interval, func() error {
+			if _, stderr, err := util.ExecuteCommandContext(ctx, w.Command); err != nil {
+				return fmt.Errorf("%v, stderr: %s", err, stderr)
+			}
+			return nil
+		})
+	case constant.WaitLog:
+		err = waitLog(ctx, env, w)
+	default:
+		err = fmt.Errorf("unknown wait type: %s", w.Type)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to wait for %s: %v", describeWait(w), err)
+	}
+	logger.Log.Infof("wait for %s condition met", describeWait(w))
+	return nil
+}
+
+func describeWait(w *config.Wait) string {
+	switch w.Type {
+	case constant.WaitHTTP:
+		return fmt.Sprintf("http %s", w.URL)
+	case constant.WaitTCP:
+		return fmt.Sprintf("tcp %s", w.Address)
+	case constant.WaitCommand:
+		return fmt.Sprintf("command %q", w.Command)
+	case constant.WaitLog:
+		return fmt.Sprintf("log %q of %s", w.Regex, w.Resource)
+	}
+	return fmt.Sprintf("%+v", *w)
+}
+
+// pollCondition checks the condition every interval until it passes or the context is done.
+func pollCondition(ctx context.Context, interval time.Duration, check func() error) error {
+	for {
+		err := check()
+		if err == nil {
+			return nil
+		}
+		if !util.SleepContext(ctx, interval) {
+			return fmt.Errorf("%v, last error: %v", ctx.Err(), err)
+		}
+	}
+}
+
+func waitHTTP(ctx context.Context, w *config.Wait, interval time.Duration) error {
+	status := w.Status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	var body *regexp.Regexp
+	if w.Body != "" {
+		var err error
+		if body, err = regexp.Compile(w.Body); err != nil {
+			return err
+		}
+	}
+
+	return pollCondition(ctx, interval, func() error {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, w.URL, http.NoBody)
+		if err != nil {
+			return err
+		}
+		response, err := http.DefaultClient.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		data, err := io.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		if response.StatusCode != status {
+			return fmt.Errorf("the response status is %d, expected %d", response.StatusCode, status)
+		}
+		if body != nil && !body.Match(data) {
+			return fmt.Errorf("the response body doesn't match %q: %s", w.Body, data)
+		}
+		return nil
+	})
+}
+
+// waitLog follows the log of the compose service or the kubernetes resource until a line matches the regex.
+func waitLog(ctx context.Context, env *StepEnv, w *config.Wait) error {
+	pattern, err := regexp.Compile(w.Regex)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var streams []io.ReadCloser
+	switch {
+	case env != nil && env.Cluster != nil:
+		streams, err = followPodLogs(ctx, env.Cluster, w)
+	case env != nil && env.Docker != nil && env.Project != nil:
+		var stream io.ReadCloser
+		stream, err = followComposeLog(ctx, env.Docker, env.Project.Name, w.Resource)
+		streams = []io.ReadCloser{stream}
+	default:
+		err = fmt.Errorf("no container or pod could be found in the environment")
+	}
+	if err != nil {
+		return err
+	}
+	return matchLog(ctx, streams, pattern)
+}
+
+func followComposeLog(ctx context.Context, cli *client.Client, project, service string) (io.ReadCloser, error) {
+	serviceName, number := getInstanceName(service)
+	container, err := findContainer(cli, project, serviceName, number)
+	if err != nil {
+		return nil, err
+	}
+	return followContainerLog(ctx, cli, container.ID)
+}
+
+// followContainerLog follows the log of the container, the stdout and stderr are merged.
+func followContainerLog(ctx context.Context, cli *client.Client, containerID string) (io.ReadCloser, error) {
+	logs, err := cli.ContainerLogs(ctx, containerID, types.ContainerLogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	reader, writer := io.Pipe()
+	go func() {
+		_, err := stdcopy.StdCopy(writer, writer, logs)
+		logs.Close()
+		writer.CloseWithError(err)
+	}()
+	return reader, nil
+}
+
+// followPodLogs follows the logs of all the containers of the pod, the resource could be a pod or its owner.
+func followPodLogs(ctx context.Context, cluster *util.K8sClusterInfo, w *config.Wait) ([]io.ReadCloser, error) {
+	obj, err := resource.NewBuilder(cluster).
+		WithScheme(scheme.Scheme, scheme.Scheme.PrioritizedVersionsAllGroups()...).
+		NamespaceParam(w.Namespace).DefaultNamespace().
+		ResourceTypeOrNameArgs(true, w.Resource).
+		SingleResourceType().
+		Do().Object()
+	if err != nil {
+		return nil, err
+	}
+	deadline, _ := ctx.Deadline()
+	requests, err := polymorphichelpers.LogsForObjectFn(cluster, obj, &v1.PodLogOptions{Follow: true}, time.Until(deadline), true)
+	if err != nil {
+		return nil, err
+	}
+	streams := make([]io.ReadCloser, 0, len(requests))
+	for _, request := range requests {
+		stream, err := request.Stream(ctx)
+		if err != nil {
+			for _, s := range streams {
+				s.Close()
+			}
+			return nil, err
+		}
+		streams = append(streams, stream)
+	}
+	return streams, nil
+}
+
+// matchLog scans the lines of the log streams until one of them matches the pattern, the streams are closed when it returns.
+func matchLog(ctx context.Context, streams []io.ReadCloser, pattern *regexp.Regexp) error {
+	matched := make(chan struct{})
+	var once sync.Once
+	var wg sync.WaitGroup
+	for _, stream := range streams {
+		wg.Add(1)
+		go func(stream io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(stream)
+			for scanner.Scan() {
+				if pattern.MatchString(scanner.Text()) {
+					once.Do(func() { close(matched) })
+					return
+				}
+			}
+		}(stream)
+	}
+	ended := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(ended)
+	}()
+	defer func() {
+		for _, stream := range streams {
+			stream.Close()
+		}
+	}()
+
+	select {
+	case <-matched:
+		return nil
+	case <-ended:
+		select {
+		case <-matched:
+			return nil
+		default:
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("the log ended without matching")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
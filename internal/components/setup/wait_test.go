@@ -0,0 +1,130 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package setup
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+
+	"github.com/apache/skywalking-infra-e2e/internal/config"
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+func TestWaitFor(t *testing.T) {
+	workDir := util.WorkDir
+	util.WorkDir = t.TempDir()
+	defer func() { util.WorkDir = workDir }()
+
+	ready := time.Now().Add(300 * time.Millisecond)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if time.Now().Before(ready) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"UP"}`))
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	tests := []struct {
+		name    string
+		env     *StepEnv
+		wait    config.Wait
+		wantErr bool
+	}{
+		{
+			name: "http",
+			wait: config.Wait{Type: constant.WaitHTTP, URL: server.URL, Body: `"status":"UP"`, Interval: "100ms"},
+		},
+		{
+			name:    "http unexpected status",
+			wait:    config.Wait{Type: constant.WaitHTTP, URL: server.URL, Status: http.StatusNoContent, Interval: "100ms", Timeout: "1s"},
+			wantErr: true,
+		},
+		{
+			name: "tcp",
+			wait: config.Wait{Type: constant.WaitTCP, Address: listener.Addr().String()},
+		},
+		{
+			name: "command",
+			wait: config.Wait{Type: constant.WaitCommand, Command: "true"},
+		},
+		{
+			name:    "log without project",
+			env:     &StepEnv{Docker: &client.Client{}},
+			wait:    config.Wait{Type: constant.WaitLog, Resource: "oap", Regex: "started", Timeout: "1s"},
+			wantErr: true,
+		},
+		{
+			name:    "command timeout",
+			wait:    config.Wait{Type: constant.WaitCommand, Command: "false", Interval: "100ms", Timeout: "500ms"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := tt.env
+			if env == nil {
+				env = &StepEnv{}
+			}
+			if err := waitFor(env, &tt.wait, 10*time.Second); (err != nil) != tt.wantErr {
+				t.Errorf("waitFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMatchLog(t *testing.T) {
+	pattern := regexp.MustCompile(`Started in \d+ms`)
+	streams := []io.ReadCloser{
+		io.NopCloser(strings.NewReader("starting\nloading\n")),
+		io.NopCloser(strings.NewReader("starting\nStarted in 120ms\n")),
+	}
+	if err := matchLog(context.Background(), streams, pattern); err != nil {
+		t.Errorf("matchLog() error = %v", err)
+	}
+
+	streams = []io.ReadCloser{io.NopCloser(strings.NewReader("starting\nloading\n"))}
+	if err := matchLog(context.Background(), streams, pattern); err == nil {
+		t.Errorf("matchLog() should fail if the log ends without matching")
+	}
+
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := matchLog(ctx, []io.ReadCloser{reader}, pattern); err == nil {
+		t.Errorf("matchLog() should fail if the context is done")
+	}
+}
@@ -36,4 +36,7 @@ type Action interface {
 
 	// Stop stops the scheduled actions.
 	Stop()
+
+	// Stats returns the statistics of the requests sent by the action.
+	Stats() *Stats
 }
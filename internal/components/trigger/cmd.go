@@ -0,0 +1,113 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+type cmdAction struct {
+	interval      time.Duration
+	times         int
+	command       string
+	executedCount int
+	stopCh        chan struct{}
+	stats         *Stats
+}
+
+func NewCMDAction(intervalStr string, times int, command string) (Action, error) {
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("trigger interval should be > 0, but was %s", interval)
+	}
+
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("trigger command should not be empty")
+	}
+
+	return &cmdAction{
+		interval:      interval,
+		times:         times,
+		command:       command,
+		executedCount: 0,
+		stopCh:        make(chan struct{}),
+		stats:         newStats(),
+	}, nil
+}
+
+func (c *cmdAction) Do() chan error {
+	t := time.NewTicker(c.interval)
+
+	logger.Log.Infof("trigger will execute command [%s] %d times with interval %s.",
+		strings.ReplaceAll(c.command, "\n", "\\n"), c.times, c.interval)
+
+	result := make(chan error)
+	sent := false
+	go func() {
+		for {
+			select {
+			case <-t.C:
+				start := time.Now()
+				err := c.execute()
+				c.stats.record(time.Since(start), err)
+
+				// `err == nil`: if no error occurs, everything is OK and send `nil` to the channel to continue.
+				// `c.times == c.executedCount`: reach to the maximum retry count and send the `err`, no matter it's `nil` or not.
+				if !sent && (err == nil || c.times == c.executedCount) {
+					result <- err
+					sent = true
+				}
+			case <-c.stopCh:
+				t.Stop()
+				result <- nil
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+func (c *cmdAction) Stop() {
+	c.stopCh <- struct{}{}
+}
+
+func (c *cmdAction) Stats() *Stats {
+	return c.stats
+}
+
+func (c *cmdAction) execute() error {
+	logger.Log.Debugf("execute command the %d time.", c.executedCount)
+	stdout, stderr, err := util.ExecuteCommand(c.command)
+	c.executedCount++
+	if err != nil {
+		logger.Log.Errorf("execute command error %v, stderr: %s", err, stderr)
+		return fmt.Errorf("execute command failed: %v, stderr: %s", err, stderr)
+	}
+
+	logger.Log.Debugf("execute command success, stdout: %s", stdout)
+	return nil
+}
@@ -0,0 +1,90 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+func TestCMDAction(t *testing.T) {
+	workDir := util.WorkDir
+	util.WorkDir = t.TempDir()
+	defer func() { util.WorkDir = workDir }()
+	// the exported environment variables should reach the command.
+	t.Setenv("E2E_TRIGGER_VALUE", "exported")
+
+	tests := []struct {
+		name     string
+		command  string
+		wantErr  bool
+		wantSent int64
+	}{{
+		name:     "Should succeed at the first attempt",
+		command:  `test "$E2E_TRIGGER_VALUE" = exported`,
+		wantSent: 1,
+	}, {
+		name:     "Should fail after all the attempts",
+		command:  "echo failed >&2 && false",
+		wantErr:  true,
+		wantSent: 3,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			action, err := NewCMDAction("10ms", 3, tt.command)
+			if err != nil {
+				t.Fatalf("NewCMDAction() error = %v", err)
+			}
+			result := action.Do()
+			select {
+			case err := <-result:
+				if (err != nil) != tt.wantErr {
+					t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatalf("Do() should return after the attempts")
+			}
+			if sent := action.Stats().Snapshot().Sent; sent != tt.wantSent {
+				t.Errorf("executed %d times, want %d", sent, tt.wantSent)
+			}
+
+			go action.Stop()
+			if err := <-result; err != nil {
+				t.Errorf("Do() should send nil after stopped, but was %v", err)
+			}
+		})
+	}
+}
+
+func TestCMDAction_Stop(t *testing.T) {
+	action, err := NewCMDAction("1h", 1, "true")
+	if err != nil {
+		t.Fatalf("NewCMDAction() error = %v", err)
+	}
+	result := action.Do()
+	go action.Stop()
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Errorf("Do() error = %v, want nil after stopped", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Stop() should unblock Do()")
+	}
+}
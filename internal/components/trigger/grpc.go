@@ -0,0 +1,303 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+)
+
+// grpcCallTimeout is the timeout of each gRPC call, including resolving the method by server reflection.
+const grpcCallTimeout = 10 * time.Second
+
+type grpcAction struct {
+	interval      time.Duration
+	times         int
+	target        string
+	service       string
+	method        string
+	template      *requestTemplate
+	files         *protoregistry.Files
+	executedCount int
+	stopCh        chan struct{}
+	stats         *Stats
+	conn          *grpc.ClientConn
+	descriptor    protoreflect.MethodDescriptor
+}
+
+// NewGRPCAction creates an action calling the unary gRPC method, the full method name should be in the format of
+// `package.Service/Method`. The method is resolved from the descriptor set file if it's provided,
+// otherwise it's resolved by the server reflection.
+func NewGRPCAction(intervalStr string, times int, target, fullMethod, body string, headers map[string]string,
+	template bool, descriptorSet string) (Action, error) {
+	interval, err := time.ParseDuration(intervalStr)
+	if err != nil {
+		return nil, err
+	}
+
+	if interval <= 0 {
+		return nil, fmt.Errorf("trigger interval should be > 0, but was %s", interval)
+	}
+
+	service, method, err := parseFullMethod(fullMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := newRequestTemplate(body, headers, template)
+	if err != nil {
+		return nil, err
+	}
+
+	var files *protoregistry.Files
+	if descriptorSet != "" {
+		if files, err = loadDescriptorSet(descriptorSet); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC connection to %s: %v", target, err)
+	}
+
+	return &grpcAction{
+		interval:      interval,
+		times:         times,
+		target:        target,
+		service:       service,
+		method:        method,
+		template:      tmpl,
+		files:         files,
+		executedCount: 0,
+		stopCh:        make(chan struct{}),
+		stats:         newStats(),
+		conn:          conn,
+	}, nil
+}
+
+func parseFullMethod(fullMethod string) (service, method string, err error) {
+	fullMethod = strings.TrimPrefix(fullMethod, "/")
+	idx := strings.LastIndexAny(fullMethod, "/.")
+	if idx <= 0 || idx == len(fullMethod)-1 {
+		return "", "", fmt.Errorf("the gRPC method should be in the format of `package.Service/Method`, but was %s", fullMethod)
+	}
+	return fullMethod[:idx], fullMethod[idx+1:], nil
+}
+
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the descriptor set file %s: %v", path, err)
+	}
+	fds := &descriptorpb.FileDescriptorSet{}
+	if err := proto.Unmarshal(data, fds); err != nil {
+		return nil, fmt.Errorf("failed to parse the descriptor set file %s: %v", path, err)
+	}
+	files, err := protodesc.NewFiles(fds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the descriptor set file %s: %v", path, err)
+	}
+	return files, nil
+}
+
+func (g *grpcAction) Do() chan error {
+	t := time.NewTicker(g.interval)
+
+	logger.Log.Infof("trigger will call gRPC method %s/%s of %s %d times with interval %s.",
+		g.service, g.method, g.target, g.times, g.interval)
+
+	result := make(chan error)
+	sent := false
+	go func() {
+		for {
+			select {
+			case <-t.C:
+				start := time.Now()
+				err := g.execute()
+				g.stats.record(time.Since(start), err)
+
+				// `err == nil`: if no error occurs, everything is OK and send `nil` to the channel to continue.
+				// `g.times == g.executedCount`: reach to the maximum retry count and send the `err`, no matter it's `nil` or not.
+				if !sent && (err == nil || g.times == g.executedCount) {
+					result <- err
+					sent = true
+				}
+			case <-g.stopCh:
+				t.Stop()
+				_ = g.conn.Close()
+				result <- nil
+				return
+			}
+		}
+	}()
+
+	return result
+}
+
+func (g *grpcAction) Stop() {
+	g.stopCh <- struct{}{}
+}
+
+func (g *grpcAction) Stats() *Stats {
+	return g.stats
+}
+
+func (g *grpcAction) execute() error {
+	logger.Log.Debugf("call gRPC method %s/%s the %d time.", g.service, g.method, g.executedCount)
+	g.executedCount++
+
+	ctx, cancel := context.WithTimeout(context.Background(), grpcCallTimeout)
+	defer cancel()
+
+	descriptor, err := g.methodDescriptor(ctx)
+	if err != nil {
+		logger.Log.Errorf("failed to resolve gRPC method %s/%s: %v", g.service, g.method, err)
+		return err
+	}
+
+	body, headers, err := g.template.render()
+	if err != nil {
+		return err
+	}
+	request := dynamicpb.NewMessage(descriptor.Input())
+	if strings.TrimSpace(body) != "" {
+		if err = protojson.Unmarshal([]byte(body), request); err != nil {
+			return fmt.Errorf("failed to parse the request body as %s: %v", descriptor.Input().FullName(), err)
+		}
+	}
+	response := dynamicpb.NewMessage(descriptor.Output())
+
+	ctx = metadata.NewOutgoingContext(ctx, metadata.New(headers))
+	if err = g.conn.Invoke(ctx, fmt.Sprintf("/%s/%s", g.service, g.method), request, response); err != nil {
+		logger.Log.Errorf("call gRPC method error %v", err)
+		return fmt.Errorf("call gRPC method failed, status code: %s, message: %s",
+			status.Code(err), status.Convert(err).Message())
+	}
+
+	logger.Log.Debugf("call gRPC method %s/%s success, response: %s", g.service, g.method, protojson.Format(response))
+	return nil
+}
+
+// methodDescriptor resolves the descriptor of the method, and caches it after resolved.
+func (g *grpcAction) methodDescriptor(ctx context.Context) (protoreflect.MethodDescriptor, error) {
+	if g.descriptor != nil {
+		return g.descriptor, nil
+	}
+
+	files := g.files
+	if files == nil {
+		var err error
+		if files, err = resolveByReflection(ctx, g.conn, g.service); err != nil {
+			return nil, err
+		}
+	}
+
+	d, err := files.FindDescriptorByName(protoreflect.FullName(g.service))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find gRPC service %s: %v", g.service, err)
+	}
+	service, ok := d.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a gRPC service", g.service)
+	}
+	method := service.Methods().ByName(protoreflect.Name(g.method))
+	if method == nil {
+		return nil, fmt.Errorf("failed to find method %s in gRPC service %s", g.method, g.service)
+	}
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return nil, fmt.Errorf("only unary gRPC method is supported, but %s/%s is streaming", g.service, g.method)
+	}
+
+	g.descriptor = method
+	return method, nil
+}
+
+// resolveByReflection resolves the file descriptors of the service and all their dependencies by the server reflection.
+func resolveByReflection(ctx context.Context, conn *grpc.ClientConn, service string) (*protoregistry.Files, error) {
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create server reflection stream: %v", err)
+	}
+	defer func() {
+		_ = stream.CloseSend()
+	}()
+
+	fds := &descriptorpb.FileDescriptorSet{}
+	added, requested := make(map[string]bool), make(map[string]bool)
+	request := &rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+	for pending := []*rpb.ServerReflectionRequest{request}; len(pending) > 0; {
+		request, pending = pending[0], pending[1:]
+		if err := stream.Send(request); err != nil {
+			return nil, fmt.Errorf("failed to send server reflection request: %v", err)
+		}
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive server reflection response: %v", err)
+		}
+		if e := response.GetErrorResponse(); e != nil {
+			return nil, fmt.Errorf("server reflection error, code: %d, message: %s", e.ErrorCode, e.ErrorMessage)
+		}
+
+		for _, raw := range response.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			fd := &descriptorpb.FileDescriptorProto{}
+			if err := proto.Unmarshal(raw, fd); err != nil {
+				return nil, fmt.Errorf("failed to parse the file descriptor: %v", err)
+			}
+			if added[fd.GetName()] {
+				continue
+			}
+			added[fd.GetName()] = true
+			fds.File = append(fds.File, fd)
+		}
+
+		// the dependencies may be not included in the response, request them by file name.
+		for _, fd := range fds.File {
+			for _, dep := range fd.GetDependency() {
+				if added[dep] || requested[dep] {
+					continue
+				}
+				requested[dep] = true
+				pending = append(pending, &rpb.ServerReflectionRequest{
+					MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+				})
+			}
+		}
+	}
+
+	return protodesc.NewFiles(fds)
+}
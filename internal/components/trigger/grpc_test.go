@@ -0,0 +1,110 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+func startGRPCServer(t *testing.T, withReflection bool) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	if withReflection {
+		reflection.Register(server)
+	}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func writeHealthDescriptorSet(t *testing.T) string {
+	fds := &descriptorpb.FileDescriptorSet{
+		File: []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(healthpb.File_grpc_health_v1_health_proto)},
+	}
+	data, err := proto.Marshal(fds)
+	if err != nil {
+		t.Fatalf("failed to marshal descriptor set: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "health.protoset")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write descriptor set: %v", err)
+	}
+	return path
+}
+
+func TestGRPCAction(t *testing.T) {
+	tests := []struct {
+		name           string
+		withReflection bool
+		descriptorSet  bool
+		body           string
+		wantErr        bool
+	}{{
+		name:           "Should call method by server reflection",
+		withReflection: true,
+		body:           `{"service": ""}`,
+	}, {
+		name:          "Should call method by descriptor set",
+		descriptorSet: true,
+		body:          `{"service": ""}`,
+	}, {
+		name:           "Should fail with non-OK status code",
+		withReflection: true,
+		body:           `{"service": "unknown"}`,
+		wantErr:        true,
+	}, {
+		name:    "Should fail without reflection or descriptor set",
+		body:    `{"service": ""}`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := startGRPCServer(t, tt.withReflection)
+			descriptorSet := ""
+			if tt.descriptorSet {
+				descriptorSet = writeHealthDescriptorSet(t)
+			}
+
+			action, err := NewGRPCAction("10ms", 1, target, "grpc.health.v1.Health/Check", tt.body,
+				map[string]string{"x-request-id": "{{ .Seq }}"}, true, descriptorSet)
+			if err != nil {
+				t.Fatalf("NewGRPCAction() error = %v", err)
+			}
+			if err := <-action.Do(); (err != nil) != tt.wantErr {
+				t.Errorf("Do() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			action.Stop()
+		})
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -16,14 +15,16 @@ type heraHTTPAction struct {
 	times         int
 	url           string
 	method        string
-	body          string
-	headers       map[string]string
+	template      *requestTemplate
 	executedCount int
 	stopCh        chan struct{}
+	stats         *Stats
 	client        *http.Client
+	validator     *responseValidator
 }
 
-func NewHeraHTTPAction(intervalStr string, times int, url, method, body string, headers map[string]string) (Action, error) {
+func NewHeraHTTPAction(intervalStr string, times int, url, method, body string, headers map[string]string,
+	template bool, expect *ExpectOptions) (Action, error) {
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
 		return nil, err
@@ -33,19 +34,26 @@ func NewHeraHTTPAction(intervalStr string, times int, url, method, body string,
 		return nil, fmt.Errorf("trigger interval should be > 0, but was %s", interval)
 	}
 
-	// there can be env variables in url, say, "http://${GATEWAY_HOST}:${GATEWAY_PORT}/test"
-	url = os.ExpandEnv(url)
+	validator, err := newResponseValidator(expect)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := newRequestTemplate(body, headers, template)
+	if err != nil {
+		return nil, err
+	}
 
 	return &heraHTTPAction{
 		interval:      interval,
 		times:         times,
 		url:           url,
 		method:        strings.ToUpper(method),
-		body:          body,
-		headers:       headers,
+		template:      tmpl,
 		executedCount: 0,
 		stopCh:        make(chan struct{}, 1),
+		stats:         newStats(),
 		client:        &http.Client{},
+		validator:     validator,
 	}, nil
 }
 
@@ -59,7 +67,9 @@ func (h *heraHTTPAction) Do() chan error {
 		for {
 			select {
 			case <-t.C:
+				start := time.Now()
 				err := h.execute()
+				h.stats.record(time.Since(start), err)
 				// send nil to result channel, then stop ticker, when request success.
 				// Otherwise, retry send http request, until send err to result when `h.times == h.executedCount`.
 				if err == nil || h.times == h.executedCount {
@@ -82,13 +92,21 @@ func (h *heraHTTPAction) Stop() {
 	h.stopCh <- struct{}{}
 }
 
+func (h *heraHTTPAction) Stats() *Stats {
+	return h.stats
+}
+
 func (h *heraHTTPAction) request() (*http.Request, error) {
-	request, err := http.NewRequest(h.method, h.url, strings.NewReader(h.body))
+	body, renderedHeaders, err := h.template.render()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(h.method, h.url, strings.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 	headers := http.Header{}
-	for k, v := range h.headers {
+	for k, v := range renderedHeaders {
 		headers[k] = []string{v}
 	}
 	request.Header = headers
@@ -96,6 +114,8 @@ func (h *heraHTTPAction) request() (*http.Request, error) {
 }
 
 func (h *heraHTTPAction) execute() error {
+	// count the attempt before building the request, so a request failing to render still uses up an attempt.
+	h.executedCount++
 	req, err := h.request()
 	if err != nil {
 		logger.Log.Errorf("failed to create new request %v", err)
@@ -103,18 +123,21 @@ func (h *heraHTTPAction) execute() error {
 	}
 	logger.Log.Debugf("request URL %s the %d time.", h.url, h.executedCount)
 	response, err := h.client.Do(req)
-	h.executedCount++
 	if err != nil {
 		logger.Log.Errorf("do request exception %v", err)
 		return err
 	}
-	_, _ = io.ReadAll(response.Body)
+	body, err := io.ReadAll(response.Body)
 	_ = response.Body.Close()
+	if err != nil {
+		logger.Log.Errorf("read response body error %v", err)
+		return err
+	}
 
 	logger.Log.Debugf("do request %v response http code %v", h.url, response.StatusCode)
-	if response.StatusCode == http.StatusOK {
-		logger.Log.Debugf("do http action %+v success.", *h)
-		return nil
+	if err = h.validator.validate(response, body); err != nil {
+		return err
 	}
-	return fmt.Errorf("do request failed, response status code: %d", response.StatusCode)
+	logger.Log.Debugf("do http action %+v success.", *h)
+	return nil
 }
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"os"
 	"strings"
 	"time"
 
@@ -32,14 +31,16 @@ type httpAction struct {
 	times         int
 	url           string
 	method        string
-	body          string
-	headers       map[string]string
+	template      *requestTemplate
 	executedCount int
 	stopCh        chan struct{}
+	stats         *Stats
 	client        *http.Client
+	validator     *responseValidator
 }
 
-func NewHTTPAction(intervalStr string, times int, url, method, body string, headers map[string]string) (Action, error) {
+func NewHTTPAction(intervalStr string, times int, url, method, body string, headers map[string]string,
+	template bool, expect *ExpectOptions) (Action, error) {
 	interval, err := time.ParseDuration(intervalStr)
 	if err != nil {
 		return nil, err
@@ -49,19 +50,26 @@ func NewHTTPAction(intervalStr string, times int, url, method, body string, head
 		return nil, fmt.Errorf("trigger interval should be > 0, but was %s", interval)
 	}
 
-	// there can be env variables in url, say, "http://${GATEWAY_HOST}:${GATEWAY_PORT}/test"
-	url = os.ExpandEnv(url)
+	validator, err := newResponseValidator(expect)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := newRequestTemplate(body, headers, template)
+	if err != nil {
+		return nil, err
+	}
 
 	return &httpAction{
 		interval:      interval,
 		times:         times,
 		url:           url,
 		method:        strings.ToUpper(method),
-		body:          body,
-		headers:       headers,
+		template:      tmpl,
 		executedCount: 0,
 		stopCh:        make(chan struct{}),
+		stats:         newStats(),
 		client:        &http.Client{},
+		validator:     validator,
 	}, nil
 }
 
@@ -76,7 +84,9 @@ func (h *httpAction) Do() chan error {
 		for {
 			select {
 			case <-t.C:
+				start := time.Now()
 				err := h.execute()
+				h.stats.record(time.Since(start), err)
 
 				// `err == nil`: if no error occurs, everything is OK and send `nil` to the channel to continue.
 				// `h.times == h.executedCount`: reach to the maximum retry count and send the `err`, no matter it's `nil` or not.
@@ -99,13 +109,21 @@ func (h *httpAction) Stop() {
 	h.stopCh <- struct{}{}
 }
 
+func (h *httpAction) Stats() *Stats {
+	return h.stats
+}
+
 func (h *httpAction) request() (*http.Request, error) {
-	request, err := http.NewRequest(h.method, h.url, strings.NewReader(h.body))
+	body, renderedHeaders, err := h.template.render()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequest(h.method, h.url, strings.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
 	headers := http.Header{}
-	for k, v := range h.headers {
+	for k, v := range renderedHeaders {
 		headers[k] = []string{v}
 	}
 	request.Header = headers
@@ -113,6 +131,8 @@ func (h *httpAction) request() (*http.Request, error) {
 }
 
 func (h *httpAction) execute() error {
+	// count the attempt before building the request, so a request failing to render still uses up an attempt.
+	h.executedCount++
 	req, err := h.request()
 	if err != nil {
 		logger.Log.Errorf("failed to create new request %v", err)
@@ -120,18 +140,21 @@ func (h *httpAction) execute() error {
 	}
 	logger.Log.Debugf("request URL %s the %d time.", h.url, h.executedCount)
 	response, err := h.client.Do(req)
-	h.executedCount++
 	if err != nil {
 		logger.Log.Errorf("do request error %v", err)
 		return err
 	}
-	_, _ = io.ReadAll(response.Body)
+	body, err := io.ReadAll(response.Body)
 	_ = response.Body.Close()
+	if err != nil {
+		logger.Log.Errorf("read response body error %v", err)
+		return err
+	}
 
 	logger.Log.Debugf("do request %v response http code %v", h.url, response.StatusCode)
-	if response.StatusCode == http.StatusOK {
-		logger.Log.Debugf("do http action %+v success.", *h)
-		return nil
+	if err = h.validator.validate(response, body); err != nil {
+		return err
 	}
-	return fmt.Errorf("do request failed, response status code: %d", response.StatusCode)
+	logger.Log.Debugf("do http action %+v success.", *h)
+	return nil
 }
@@ -0,0 +1,312 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+)
+
+// loadTickInterval is the precision of the rate limiter.
+const loadTickInterval = 10 * time.Millisecond
+
+// LoadOptions configures the rate based load mode of the http trigger.
+type LoadOptions struct {
+	RPS         int
+	Concurrency int
+	RampUp      string
+	Steady      string
+	RampDown    string
+}
+
+// loadStage is a period of the load, the rate changes linearly from `fromRPS` to `toRPS`,
+// the stage never ends if the duration is 0.
+type loadStage struct {
+	name     string
+	duration time.Duration
+	fromRPS  float64
+	toRPS    float64
+
+	sent      int64
+	succeeded int64
+	failed    int64
+}
+
+// rateAt returns the expected requests per second after the stage has run for elapsed time.
+func (s *loadStage) rateAt(elapsed time.Duration) float64 {
+	if s.duration <= 0 {
+		return s.toRPS
+	}
+	return s.fromRPS + (s.toRPS-s.fromRPS)*float64(elapsed)/float64(s.duration)
+}
+
+type httpLoadAction struct {
+	times       int
+	url         string
+	method      string
+	template    *requestTemplate
+	rps         int
+	concurrency int
+	stages      []*loadStage
+	failedCount int64
+	client      *http.Client
+	validator   *responseValidator
+	stats       *Stats
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	result   chan error
+	sendOnce sync.Once
+}
+
+func NewHTTPLoadAction(times int, url, method, body string, headers map[string]string,
+	template bool, expect *ExpectOptions, options LoadOptions) (Action, error) {
+	if options.RPS <= 0 {
+		return nil, fmt.Errorf("trigger load rps should be > 0, but was %d", options.RPS)
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = 1
+	}
+	stages, err := buildLoadStages(options)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := newResponseValidator(expect)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := newRequestTemplate(body, headers, template)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &httpLoadAction{
+		times:       times,
+		url:         url,
+		method:      strings.ToUpper(method),
+		template:    tmpl,
+		rps:         options.RPS,
+		concurrency: options.Concurrency,
+		stages:      stages,
+		client:      &http.Client{},
+		validator:   validator,
+		stats:       newStats(),
+		ctx:         ctx,
+		cancel:      cancel,
+		result:      make(chan error, 1),
+	}, nil
+}
+
+func buildLoadStages(options LoadOptions) ([]*loadStage, error) {
+	parse := func(value, name string) (time.Duration, error) {
+		if value == "" {
+			return 0, nil
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse trigger load %s: %v", name, err)
+		}
+		if d < 0 {
+			return 0, fmt.Errorf("trigger load %s should be >= 0, but was %s", name, d)
+		}
+		return d, nil
+	}
+	rampUp, err := parse(options.RampUp, "ramp-up")
+	if err != nil {
+		return nil, err
+	}
+	steady, err := parse(options.Steady, "steady")
+	if err != nil {
+		return nil, err
+	}
+	rampDown, err := parse(options.RampDown, "ramp-down")
+	if err != nil {
+		return nil, err
+	}
+	if rampDown > 0 && steady == 0 {
+		return nil, fmt.Errorf("trigger load ramp-down requires steady to be set, otherwise the steady stage never ends")
+	}
+
+	rps := float64(options.RPS)
+	stages := make([]*loadStage, 0, 3)
+	if rampUp > 0 {
+		stages = append(stages, &loadStage{name: "ramp-up", duration: rampUp, fromRPS: 0, toRPS: rps})
+	}
+	// keep the target rate until the trigger is stopped if the steady duration is not set.
+	stages = append(stages, &loadStage{name: "steady", duration: steady, fromRPS: rps, toRPS: rps})
+	if rampDown > 0 {
+		stages = append(stages, &loadStage{name: "ramp-down", duration: rampDown, fromRPS: rps, toRPS: 0})
+	}
+	return stages, nil
+}
+
+func (h *httpLoadAction) Do() chan error {
+	logger.Log.Infof("trigger will request URL %s with %d rps by %d workers.", h.url, h.rps, h.concurrency)
+
+	jobs := make(chan *loadStage, h.concurrency)
+	for i := 0; i < h.concurrency; i++ {
+		h.wg.Add(1)
+		go h.work(jobs)
+	}
+
+	h.wg.Add(1)
+	go h.dispatch(jobs)
+
+	go func() {
+		h.wg.Wait()
+		// all the stages are finished but there is still no result.
+		if h.ctx.Err() == nil {
+			h.sendResult(fmt.Errorf("trigger load of URL %s finished without any successful request", h.url))
+		}
+	}()
+
+	return h.result
+}
+
+// dispatch sends the jobs to the workers according to the rate of each stage.
+func (h *httpLoadAction) dispatch(jobs chan<- *loadStage) {
+	defer func() {
+		close(jobs)
+		h.wg.Done()
+	}()
+
+	t := time.NewTicker(loadTickInterval)
+	defer t.Stop()
+
+	for _, stage := range h.stages {
+		logger.Log.Debugf("trigger load stage %s started", stage.name)
+		start, last := time.Now(), time.Now()
+		tokens := 0.0
+		for stage.duration <= 0 || time.Since(start) < stage.duration {
+			select {
+			case <-h.ctx.Done():
+				return
+			case now := <-t.C:
+				tokens += stage.rateAt(now.Sub(start)) * now.Sub(last).Seconds()
+				last = now
+			}
+			for ; tokens >= 1; tokens-- {
+				select {
+				case <-h.ctx.Done():
+					return
+				case jobs <- stage:
+				}
+			}
+		}
+	}
+	logger.Log.Infof("all the trigger load stages of URL %s are finished", h.url)
+}
+
+func (h *httpLoadAction) work(jobs <-chan *loadStage) {
+	defer h.wg.Done()
+
+	for stage := range jobs {
+		start := time.Now()
+		err := h.execute()
+		// the request is canceled by stopping the trigger, don't count it.
+		if err != nil && h.ctx.Err() != nil {
+			continue
+		}
+		h.stats.record(time.Since(start), err)
+		atomic.AddInt64(&stage.sent, 1)
+		if err == nil {
+			atomic.AddInt64(&stage.succeeded, 1)
+			h.sendResult(nil)
+			continue
+		}
+
+		atomic.AddInt64(&stage.failed, 1)
+		// reach to the maximum retry count before any success.
+		if failed := atomic.AddInt64(&h.failedCount, 1); h.times > 0 && failed == int64(h.times) {
+			h.sendResult(err)
+		}
+	}
+}
+
+// sendResult makes sure the result channel only receives the first result.
+func (h *httpLoadAction) sendResult(err error) {
+	h.sendOnce.Do(func() {
+		h.result <- err
+	})
+}
+
+func (h *httpLoadAction) Stop() {
+	h.cancel()
+	h.wg.Wait()
+	h.sendResult(nil)
+
+	for _, stage := range h.stages {
+		logger.Log.Infof("trigger load stage %s of URL %s: sent %d, succeeded %d, failed %d", stage.name, h.url,
+			atomic.LoadInt64(&stage.sent), atomic.LoadInt64(&stage.succeeded), atomic.LoadInt64(&stage.failed))
+	}
+}
+
+func (h *httpLoadAction) Stats() *Stats {
+	return h.stats
+}
+
+func (h *httpLoadAction) request() (*http.Request, error) {
+	body, renderedHeaders, err := h.template.render()
+	if err != nil {
+		return nil, err
+	}
+	request, err := http.NewRequestWithContext(h.ctx, h.method, h.url, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	headers := http.Header{}
+	for k, v := range renderedHeaders {
+		headers[k] = []string{v}
+	}
+	request.Header = headers
+	return request, err
+}
+
+func (h *httpLoadAction) execute() error {
+	req, err := h.request()
+	if err != nil {
+		logger.Log.Errorf("failed to create new request %v", err)
+		return err
+	}
+	response, err := h.client.Do(req)
+	if err != nil {
+		logger.Log.Debugf("do request error %v", err)
+		return err
+	}
+	body, err := io.ReadAll(response.Body)
+	_ = response.Body.Close()
+	if err != nil {
+		logger.Log.Debugf("read response body error %v", err)
+		return err
+	}
+
+	if err = h.validator.validate(response, body); err != nil {
+		logger.Log.Debugf("do request %v failed: %v", h.url, err)
+		return err
+	}
+	return nil
+}
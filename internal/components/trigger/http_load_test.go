@@ -0,0 +1,94 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func Test_buildLoadStages(t *testing.T) {
+	tests := []struct {
+		name    string
+		options LoadOptions
+		want    []string
+		wantErr bool
+	}{{
+		name:    "Should keep steady without any stage duration",
+		options: LoadOptions{RPS: 10},
+		want:    []string{"steady"},
+	}, {
+		name:    "Should build all the stages",
+		options: LoadOptions{RPS: 10, RampUp: "10s", Steady: "1m", RampDown: "10s"},
+		want:    []string{"ramp-up", "steady", "ramp-down"},
+	}, {
+		name:    "Should fail with ramp-down when steady never ends",
+		options: LoadOptions{RPS: 10, RampUp: "10s", RampDown: "10s"},
+		wantErr: true,
+	}, {
+		name:    "Should fail with invalid duration",
+		options: LoadOptions{RPS: 10, RampUp: "abc"},
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := buildLoadStages(tt.options)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("buildLoadStages() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(stages) != len(tt.want) {
+				t.Fatalf("buildLoadStages() got %d stages, want %d", len(stages), len(tt.want))
+			}
+			for i := range stages {
+				if stages[i].name != tt.want[i] {
+					t.Errorf("stage[%d] = %s, want %s", i, stages[i].name, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHTTPLoadAction(t *testing.T) {
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&received, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	action, err := NewHTTPLoadAction(3, server.URL, "get", "", nil, false, nil, LoadOptions{RPS: 100, Concurrency: 4})
+	if err != nil {
+		t.Fatalf("NewHTTPLoadAction() error = %v", err)
+	}
+	if err := <-action.Do(); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	action.Stop()
+
+	load := action.(*httpLoadAction)
+	// the requests canceled by stopping may still be received by the server.
+	if load.stages[0].sent > atomic.LoadInt64(&received) || load.stages[0].succeeded != load.stages[0].sent {
+		t.Errorf("sent %d, succeeded %d, server received %d", load.stages[0].sent, load.stages[0].succeeded, received)
+	}
+	if received < 5 {
+		t.Errorf("server received %d requests, want at least 5", received)
+	}
+}
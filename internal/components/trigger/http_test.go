@@ -0,0 +1,63 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package trigger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHTTPActions_RenderError(t *testing.T) {
+	var received int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&received, 1)
+	}))
+	defer server.Close()
+
+	// the body always fails to render, since randomInt requires max > min.
+	body := `{"id": {{ randomInt 5 1 }}}`
+	httpAction, err := NewHTTPAction("10ms", 2, server.URL, "post", body, nil, true, nil)
+	if err != nil {
+		t.Fatalf("NewHTTPAction() error = %v", err)
+	}
+	heraHTTPAction, err := NewHeraHTTPAction("10ms", 2, server.URL, "post", body, nil, true, nil)
+	if err != nil {
+		t.Fatalf("NewHeraHTTPAction() error = %v", err)
+	}
+
+	for name, action := range map[string]Action{"http": httpAction, "hera-http": heraHTTPAction} {
+		select {
+		case err := <-action.Do():
+			if err == nil {
+				t.Errorf("%s Do() should return the render error", name)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s Do() should return after the attempts are used up", name)
+		}
+		if sent := action.Stats().Snapshot().Sent; sent != 2 {
+			t.Errorf("%s sent %d times, want 2", name, sent)
+		}
+	}
+	if received != 0 {
+		t.Errorf("the server received %d requests, want none", received)
+	}
+}
@@ -0,0 +1,120 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// ExpectOptions configures the success criteria of the http response,
+// only status code 200 is accepted if it's not specified.
+type ExpectOptions struct {
+	Status    []int
+	Body      string
+	JSONPath  string
+	JSONValue string
+	Headers   map[string]string
+}
+
+type responseValidator struct {
+	status    map[int]bool
+	body      *regexp.Regexp
+	jsonPath  *jsonpath.JSONPath
+	jsonValue *regexp.Regexp
+	headers   map[string]*regexp.Regexp
+}
+
+func newResponseValidator(expect *ExpectOptions) (*responseValidator, error) {
+	v := &responseValidator{status: map[int]bool{http.StatusOK: true}}
+	if expect == nil {
+		return v, nil
+	}
+
+	var err error
+	if len(expect.Status) > 0 {
+		v.status = make(map[int]bool, len(expect.Status))
+		for _, status := range expect.Status {
+			v.status[status] = true
+		}
+	}
+	if expect.Body != "" {
+		if v.body, err = regexp.Compile(expect.Body); err != nil {
+			return nil, fmt.Errorf("failed to compile the expected body pattern %s: %v", expect.Body, err)
+		}
+	}
+	if expect.JSONPath != "" {
+		v.jsonPath = jsonpath.New("expect")
+		if err = v.jsonPath.Parse(expect.JSONPath); err != nil {
+			return nil, fmt.Errorf("failed to parse the expected json path %s: %v", expect.JSONPath, err)
+		}
+		if expect.JSONValue != "" {
+			if v.jsonValue, err = regexp.Compile(expect.JSONValue); err != nil {
+				return nil, fmt.Errorf("failed to compile the expected json value pattern %s: %v", expect.JSONValue, err)
+			}
+		}
+	}
+	if len(expect.Headers) > 0 {
+		v.headers = make(map[string]*regexp.Regexp, len(expect.Headers))
+		for name, pattern := range expect.Headers {
+			if v.headers[name], err = regexp.Compile(pattern); err != nil {
+				return nil, fmt.Errorf("failed to compile the expected header %s pattern %s: %v", name, pattern, err)
+			}
+		}
+	}
+	return v, nil
+}
+
+// validate checks whether the response meets all the success criteria.
+func (v *responseValidator) validate(response *http.Response, body []byte) error {
+	if !v.status[response.StatusCode] {
+		return fmt.Errorf("do request failed, response status code: %d", response.StatusCode)
+	}
+
+	for name, pattern := range v.headers {
+		if value := response.Header.Get(name); !pattern.MatchString(value) {
+			return fmt.Errorf("response header %s: %q does not match the pattern %s", name, value, pattern)
+		}
+	}
+
+	if v.body != nil && !v.body.Match(body) {
+		return fmt.Errorf("response body does not match the pattern %s", v.body)
+	}
+
+	if v.jsonPath != nil {
+		var data any
+		if err := json.Unmarshal(body, &data); err != nil {
+			return fmt.Errorf("failed to parse the response body as json: %v", err)
+		}
+		result := bytes.Buffer{}
+		if err := v.jsonPath.Execute(&result, data); err != nil {
+			return fmt.Errorf("failed to find the json path in response body: %v", err)
+		}
+		if v.jsonValue == nil && result.Len() == 0 {
+			return fmt.Errorf("the json path result of response body is empty")
+		}
+		if v.jsonValue != nil && !v.jsonValue.Match(result.Bytes()) {
+			return fmt.Errorf("the json path result %q does not match the pattern %s", result.String(), v.jsonValue)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"net/http"
+	"testing"
+)
+
+func Test_responseValidator_validate(t *testing.T) {
+	jsonHeader := http.Header{"Content-Type": []string{"application/json"}}
+	tests := []struct {
+		name    string
+		expect  *ExpectOptions
+		status  int
+		header  http.Header
+		body    string
+		wantErr bool
+	}{{
+		name:   "Should accept 200 by default",
+		status: http.StatusOK,
+	}, {
+		name:    "Should reject other status by default",
+		status:  http.StatusNoContent,
+		wantErr: true,
+	}, {
+		name:   "Should accept configured status",
+		expect: &ExpectOptions{Status: []int{200, 204}},
+		status: http.StatusNoContent,
+	}, {
+		name:    "Should reject unmatched body",
+		expect:  &ExpectOptions{Body: `"status":\s*"UP"`},
+		status:  http.StatusOK,
+		body:    `{"status": "DOWN"}`,
+		wantErr: true,
+	}, {
+		name:   "Should accept matched json path",
+		expect: &ExpectOptions{JSONPath: "{.data[0].name}", JSONValue: "^foo$"},
+		status: http.StatusOK,
+		body:   `{"data": [{"name": "foo"}]}`,
+	}, {
+		name:    "Should reject missing json path",
+		expect:  &ExpectOptions{JSONPath: "{.data[0].name}"},
+		status:  http.StatusOK,
+		body:    `{"data": []}`,
+		wantErr: true,
+	}, {
+		name:   "Should accept matched headers",
+		expect: &ExpectOptions{Headers: map[string]string{"Content-Type": "^application/json"}},
+		status: http.StatusOK,
+		header: jsonHeader,
+	}, {
+		name:    "Should reject unmatched headers",
+		expect:  &ExpectOptions{Headers: map[string]string{"Content-Type": "^text/html"}},
+		status:  http.StatusOK,
+		header:  jsonHeader,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := newResponseValidator(tt.expect)
+			if err != nil {
+				t.Fatalf("newResponseValidator() error = %v", err)
+			}
+			response := &http.Response{StatusCode: tt.status, Header: tt.header}
+			if err := v.validate(response, []byte(tt.body)); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
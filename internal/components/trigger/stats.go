@@ -0,0 +1,122 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"math/bits"
+	"sync"
+	"time"
+)
+
+// latencySubBuckets is the number of the histogram buckets in each power of two range of the latency,
+// the latencies below it are recorded exactly, and the relative error of the others is within 1/latencySubBuckets.
+const latencySubBuckets = 64
+
+// Stats records the results of the requests sent by a trigger action.
+type Stats struct {
+	lock      sync.Mutex
+	sent      int64
+	succeeded int64
+	failed    int64
+	// latencies is the histogram of the latencies in milliseconds, the memory is bounded however many requests are sent.
+	latencies []int64
+}
+
+// StatsSnapshot is the statistics of a trigger action at a point in time.
+type StatsSnapshot struct {
+	Sent      int64
+	Succeeded int64
+	Failed    int64
+	P50       time.Duration
+	P90       time.Duration
+	P99       time.Duration
+}
+
+func newStats() *Stats {
+	return &Stats{}
+}
+
+// record records the result of a request.
+func (s *Stats) record(latency time.Duration, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sent++
+	if err == nil {
+		s.succeeded++
+	} else {
+		s.failed++
+	}
+	bucket := latencyBucket(latency.Milliseconds())
+	if bucket >= len(s.latencies) {
+		s.latencies = append(s.latencies, make([]int64, bucket+1-len(s.latencies))...)
+	}
+	s.latencies[bucket]++
+}
+
+// Snapshot returns the current statistics.
+func (s *Stats) Snapshot() StatsSnapshot {
+	s.lock.Lock()
+	latencies := make([]int64, len(s.latencies))
+	copy(latencies, s.latencies)
+	snapshot := StatsSnapshot{Sent: s.sent, Succeeded: s.succeeded, Failed: s.failed}
+	s.lock.Unlock()
+
+	snapshot.P50 = percentile(latencies, snapshot.Sent, 50)
+	snapshot.P90 = percentile(latencies, snapshot.Sent, 90)
+	snapshot.P99 = percentile(latencies, snapshot.Sent, 99)
+	return snapshot
+}
+
+// latencyBucket returns the index of the histogram bucket of the latency in milliseconds.
+func latencyBucket(ms int64) int {
+	if ms < latencySubBuckets {
+		if ms < 0 {
+			return 0
+		}
+		return int(ms)
+	}
+	shift := bits.Len64(uint64(ms)) - bits.Len64(latencySubBuckets)
+	return shift*latencySubBuckets + int(ms>>shift)
+}
+
+// bucketLatency returns the lower bound of the latencies in the histogram bucket.
+func bucketLatency(bucket int) time.Duration {
+	if bucket < latencySubBuckets {
+		return time.Duration(bucket) * time.Millisecond
+	}
+	shift := bucket/latencySubBuckets - 1
+	return time.Duration(int64(bucket-shift*latencySubBuckets)<<shift) * time.Millisecond
+}
+
+// percentile returns the nearest-rank percentile of the histogram of total latencies.
+func percentile(histogram []int64, total int64, p int64) time.Duration {
+	if total == 0 {
+		return 0
+	}
+	rank := (p*total + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	var count int64
+	for bucket, n := range histogram {
+		if count += n; count >= rank {
+			return bucketLatency(bucket)
+		}
+	}
+	return bucketLatency(len(histogram) - 1)
+}
@@ -0,0 +1,74 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStats_Snapshot(t *testing.T) {
+	stats := newStats()
+	for i := 100; i >= 1; i-- {
+		var err error
+		if i%10 == 0 {
+			err = errors.New("failed")
+		}
+		stats.record(time.Duration(i)*time.Millisecond, err)
+	}
+
+	want := StatsSnapshot{
+		Sent:      100,
+		Succeeded: 90,
+		Failed:    10,
+		P50:       50 * time.Millisecond,
+		P90:       90 * time.Millisecond,
+		P99:       99 * time.Millisecond,
+	}
+	if got := stats.Snapshot(); got != want {
+		t.Errorf("Snapshot() = %+v, want %+v", got, want)
+	}
+	if got := newStats().Snapshot(); got != (StatsSnapshot{}) {
+		t.Errorf("Snapshot() of empty stats = %+v, want zero value", got)
+	}
+}
+
+func TestStats_LatencyHistogram(t *testing.T) {
+	stats := newStats()
+	for i := 1; i <= 100000; i++ {
+		stats.record(time.Duration(i)*time.Millisecond, nil)
+	}
+	if len(stats.latencies) > latencyBucket(100000)+1 {
+		t.Errorf("the histogram has %d buckets, should be bounded by the max latency", len(stats.latencies))
+	}
+
+	snapshot := stats.Snapshot()
+	for _, tt := range []struct {
+		got, want time.Duration
+	}{{snapshot.P50, 50 * time.Second}, {snapshot.P90, 90 * time.Second}, {snapshot.P99, 99 * time.Second}} {
+		if diff := tt.want - tt.got; diff < 0 || diff > tt.want/latencySubBuckets {
+			t.Errorf("percentile = %s, want %s within 1/%d", tt.got, tt.want, latencySubBuckets)
+		}
+	}
+
+	for _, ms := range []int64{0, 63, 64, 127, 128, 1000, 123456789} {
+		if lower := bucketLatency(latencyBucket(ms)).Milliseconds(); lower > ms || ms-lower > ms/latencySubBuckets {
+			t.Errorf("the bucket of %dms starts from %dms", ms, lower)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/third-party/go/template"
+)
+
+const randomStringLetters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// requestTemplate renders the body and headers of each request, so that every request could carry unique values.
+// The body and headers are sent as they are if the templating is not enabled.
+type requestTemplate struct {
+	enabled    bool
+	rawBody    string
+	rawHeaders map[string]string
+
+	body    *template.Template
+	headers map[string]*template.Template
+	seq     int64
+
+	countersLock sync.Mutex
+	counters     map[string]int64
+}
+
+// requestData is the data used to render the templates of a single request.
+type requestData struct {
+	// Seq is the sequence number of the request, starts from 1.
+	Seq int64
+}
+
+func newRequestTemplate(body string, headers map[string]string, enabled bool) (*requestTemplate, error) {
+	r := &requestTemplate{
+		enabled:    enabled,
+		rawBody:    body,
+		rawHeaders: headers,
+		headers:    make(map[string]*template.Template, len(headers)),
+		counters:   make(map[string]int64),
+	}
+	if !enabled {
+		return r, nil
+	}
+
+	var err error
+	if r.body, err = template.New("body").Funcs(r.funcMap()).Parse(body); err != nil {
+		return nil, fmt.Errorf("failed to parse the request body template: %v", err)
+	}
+	for name, value := range headers {
+		if r.headers[name], err = template.New(name).Funcs(r.funcMap()).Parse(value); err != nil {
+			return nil, fmt.Errorf("failed to parse the request header %s template: %v", name, err)
+		}
+	}
+	return r, nil
+}
+
+// render renders the body and headers of the next request.
+func (r *requestTemplate) render() (body string, headers map[string]string, err error) {
+	data := &requestData{Seq: atomic.AddInt64(&r.seq, 1)}
+	if !r.enabled {
+		headers = make(map[string]string, len(r.rawHeaders))
+		for name, value := range r.rawHeaders {
+			headers[name] = value
+		}
+		return r.rawBody, headers, nil
+	}
+
+	if body, err = execute(r.body, data); err != nil {
+		return "", nil, fmt.Errorf("failed to render the request body: %v", err)
+	}
+	headers = make(map[string]string, len(r.headers))
+	for name, tmpl := range r.headers {
+		if headers[name], err = execute(tmpl, data); err != nil {
+			return "", nil, fmt.Errorf("failed to render the request header %s: %v", name, err)
+		}
+	}
+	return body, headers, nil
+}
+
+func execute(tmpl *template.Template, data *requestData) (string, error) {
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func (r *requestTemplate) funcMap() template.FuncMap {
+	return template.FuncMap{
+		// Counter:
+		"counter": r.counter,
+
+		// Random:
+		"uuid":         randomUUID,
+		"randomInt":    randomInt,
+		"randomString": randomString,
+		"pick":         pick,
+
+		// Time:
+		"now":       time.Now,
+		"timestamp": timestamp,
+	}
+}
+
+// counter increases the counter with the given name and returns the new value, starts from 1.
+func (r *requestTemplate) counter(name string) int64 {
+	r.countersLock.Lock()
+	defer r.countersLock.Unlock()
+	r.counters[name]++
+	return r.counters[name]
+}
+
+// randomUUID generates a random (version 4) UUID.
+func randomUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// randomInt returns a random number in [min, max).
+func randomInt(min, max int64) (int64, error) {
+	if max <= min {
+		return 0, fmt.Errorf("randomInt requires max > min, but was min: %d, max: %d", min, max)
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(max-min))
+	if err != nil {
+		return 0, err
+	}
+	return min + n.Int64(), nil
+}
+
+// randomString returns a random alphanumeric string with the given length.
+func randomString(length int) (string, error) {
+	b := make([]byte, length)
+	for i := range b {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(randomStringLetters))))
+		if err != nil {
+			return "", err
+		}
+		b[i] = randomStringLetters[n.Int64()]
+	}
+	return string(b), nil
+}
+
+// pick returns a random one of the given values.
+func pick(values ...any) (any, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("pick requires at least one value")
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(values))))
+	if err != nil {
+		return nil, err
+	}
+	return values[n.Int64()], nil
+}
+
+// timestamp returns the current unix timestamp in milliseconds.
+func timestamp() int64 {
+	return time.Now().UnixNano() / int64(time.Millisecond)
+}
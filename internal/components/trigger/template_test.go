@@ -0,0 +1,66 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+package trigger
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+)
+
+func Test_requestTemplate_render(t *testing.T) {
+	tmpl, err := newRequestTemplate(
+		`{"seq":{{ .Seq }},"order":{{ counter "order" }},"id":"{{ uuid }}","name":"{{ pick "foo" "bar" }}"}`,
+		map[string]string{"X-Request-Id": "req-{{ .Seq }}", "Content-Type": "application/json"},
+		true,
+	)
+	if err != nil {
+		t.Fatalf("newRequestTemplate() error = %v", err)
+	}
+
+	bodyPattern := regexp.MustCompile(`^{"seq":2,"order":2,"id":"[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}","name":"(foo|bar)"}$`)
+	for seq := 1; seq <= 2; seq++ {
+		body, headers, err := tmpl.render()
+		if err != nil {
+			t.Fatalf("render() error = %v", err)
+		}
+		if seq == 2 && !bodyPattern.MatchString(body) {
+			t.Errorf("render() body = %s, want match %s", body, bodyPattern)
+		}
+		if want := fmt.Sprintf("req-%d", seq); headers["X-Request-Id"] != want {
+			t.Errorf("render() header X-Request-Id = %s, want %s", headers["X-Request-Id"], want)
+		}
+		if headers["Content-Type"] != "application/json" {
+			t.Errorf("render() header Content-Type = %s, want application/json", headers["Content-Type"])
+		}
+	}
+}
+
+func Test_requestTemplate_renderLiteral(t *testing.T) {
+	body := `{"query":"{ service(id: \"{{ id }}\") }"}`
+	tmpl, err := newRequestTemplate(body, map[string]string{"X-Request-Id": "{{ .Seq }}"}, false)
+	if err != nil {
+		t.Fatalf("newRequestTemplate() error = %v", err)
+	}
+	rendered, headers, err := tmpl.render()
+	if err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if rendered != body || headers["X-Request-Id"] != "{{ .Seq }}" {
+		t.Errorf("render() = %s, %v, want the body and headers as they are", rendered, headers)
+	}
+}
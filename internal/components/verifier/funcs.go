@@ -23,6 +23,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"os"
 	"regexp"
 	"strings"
 
@@ -52,6 +53,9 @@ var customFuncMap = map[string]any{
 
 	// Regex:
 	"regexp": regexpMatch,
+
+	// Environment:
+	"env": os.Getenv,
 }
 
 func base64encode(s string) string {
@@ -20,37 +20,46 @@ package config
 
 import (
 	"fmt"
-	"os"
 	"time"
 
 	"github.com/apache/skywalking-infra-e2e/internal/constant"
-	"github.com/apache/skywalking-infra-e2e/internal/logger"
 	"github.com/apache/skywalking-infra-e2e/internal/util"
 )
 
 // E2EConfig corresponds to configuration file e2e.yaml.
 type E2EConfig struct {
-	Setup   Setup   `yaml:"setup"`
-	Cleanup Cleanup `yaml:"cleanup"`
-	Trigger Trigger `yaml:"trigger"`
-	Assert  Assert  `yaml:"assert"`
-	Verify  Verify  `yaml:"verify"`
+	// Extends is the base config file, this file is deep merged over it.
+	Extends string `yaml:"extends"`
+	// Merge is the merge modes of the lists when extending the base config, keyed by their paths,
+	// such as `setup.steps` and `verify.cases`, the lists are replaced by default.
+	Merge map[string]string `yaml:"merge"`
+	// Vars are exported as environment variables when the config is loaded.
+	Vars map[string]string `yaml:"vars"`
+	// Matrix makes `e2e run` run the whole process once per combination of its values.
+	Matrix Matrix `yaml:"matrix"`
+
+	Setup   Setup    `yaml:"setup"`
+	Cleanup Cleanup  `yaml:"cleanup"`
+	Trigger Triggers `yaml:"trigger"`
+	Assert  Assert   `yaml:"assert"`
+	Verify  Verify   `yaml:"verify"`
 }
 
 type Setup struct {
-	Env                   string    `yaml:"env"`
-	File                  string    `yaml:"file"`
-	Kubeconfig            string    `yaml:"kubeconfig"`
-	Steps                 []Step    `yaml:"steps"`
-	Timeout               any       `yaml:"timeout"`
-	InitSystemEnvironment string    `yaml:"init-system-environment"`
-	Kind                  KindSetup `yaml:"kind"`
+	Env                   string       `yaml:"env"`
+	File                  string       `yaml:"file"`
+	Kubeconfig            string       `yaml:"kubeconfig"`
+	Steps                 []Step       `yaml:"steps"`
+	Timeout               any          `yaml:"timeout"`
+	InitSystemEnvironment string       `yaml:"init-system-environment"`
+	Kind                  KindSetup    `yaml:"kind"`
+	Compose               ComposeSetup `yaml:"compose"`
 
 	timeout time.Duration
 }
 
 func (s *Setup) Finalize() error {
-	interval, err := parseInterval(s.Timeout, "setup.timeout")
+	interval, err := parseInterval(s.Timeout, "setup.timeout", time.Second)
 	if err != nil {
 		return err
 	}
@@ -81,6 +90,56 @@ type KindSetup struct {
 	ExposePorts  []KindExposePort `yaml:"expose-ports"`
 }
 
+// ComposeSetup configures how the compose environment is set up.
+type ComposeSetup struct {
+	// Driver is the compose command to run, auto, standalone or plugin, it's auto by default.
+	Driver string `yaml:"driver"`
+	// Files are the compose files applied after `setup.file` in order, the later files override the former ones.
+	Files []string `yaml:"files"`
+	// Profiles are the compose profiles to enable.
+	Profiles []string `yaml:"profiles"`
+	// ProjectName is the compose project name, the CI_JOB_ID or "hera-e2e" is used if it's not set.
+	ProjectName string `yaml:"project-name"`
+	// Services configure how to wait for the compose services to be ready.
+	Services []ComposeService `yaml:"services"`
+}
+
+// ComposeService configures how to wait for a compose service to be ready, besides its published ports are open.
+type ComposeService struct {
+	Name string `yaml:"name"`
+	// Timeout is the timeout of waiting for the service, it's the setup.timeout by default.
+	Timeout string        `yaml:"timeout"`
+	Waits   []ComposeWait `yaml:"wait"`
+}
+
+// GetTimeout parses the timeout of the service, zero means it's not set.
+func (s *ComposeService) GetTimeout() (time.Duration, error) {
+	if s.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the timeout of service %s: %v", s.Name, err)
+	}
+	return d, nil
+}
+
+// ComposeWait is a condition of a compose service to be ready.
+type ComposeWait struct {
+	// Type is the type of the condition, healthy, http, log or exec.
+	Type string `yaml:"type"`
+	// Port is the container port requested by the http condition, it should be published.
+	Port int `yaml:"port"`
+	// Path is the path requested by the http condition, it's "/" by default.
+	Path string `yaml:"path"`
+	// Status is the expected response status of the http condition, it's 200 by default.
+	Status int `yaml:"status"`
+	// Regex is the regular expression matching a line of the container log in the log condition.
+	Regex string `yaml:"regex"`
+	// Command is executed in the container by `/bin/sh -c` in the exec condition, which should exit with 0.
+	Command string `yaml:"command"`
+}
+
 type KindExposePort struct {
 	Namespace string `yaml:"namespace"`
 	Resource  string `yaml:"resource"`
@@ -102,10 +161,21 @@ type Assert struct {
 }
 
 type AssertCase struct {
-	Name     string `yaml:"name"`
-	Query    string `yaml:"query"`
-	Actual   string `yaml:"actual"`
-	Expected string `yaml:"expected"`
+	Name     string   `yaml:"name"`
+	Query    string   `yaml:"query"`
+	Actual   string   `yaml:"actual"`
+	Expected string   `yaml:"expected"`
+	Includes []string `yaml:"includes"`
+	// Tags are used to select the cases in command line, the included cases inherit the tags.
+	Tags []string `yaml:"tags"`
+	// Retry overrides the fields of the suite retry strategy it sets.
+	Retry *VerifyRetryStrategy `yaml:"retry"`
+	// Timeout is the hard timeout of the case, including all the attempts and the intervals.
+	Timeout string `yaml:"timeout"`
+}
+
+type ReusingAssertCases struct {
+	Cases []AssertCase `yaml:"cases"`
 }
 
 // GetActual resolves the absolute file path of the actual data file.
@@ -119,17 +189,29 @@ func (v *AssertCase) GetExpected() string {
 }
 
 func (s *Setup) GetFile() string {
-	// expand the file path with system environment
-	file := os.ExpandEnv(s.File)
-	file = util.ResolveAbs(file)
-	return file
+	// expand the file path with system environment, the errors have been reported when the config is loaded
+	file, _ := util.ExpandEnv(s.File)
+	return util.ResolveAbs(file)
+}
+
+// GetComposeFiles resolves the absolute file paths of the compose files, including the `setup.file`.
+func (s *Setup) GetComposeFiles() []string {
+	files := make([]string, 0, len(s.Compose.Files)+1)
+	if s.File != "" {
+		files = append(files, s.GetFile())
+	}
+	for _, f := range s.Compose.Files {
+		// the errors have been reported when the config is loaded
+		file, _ := util.ExpandEnv(f)
+		files = append(files, util.ResolveAbs(file))
+	}
+	return files
 }
 
 func (s *Setup) GetKubeconfig() string {
-	// expand the file path with system environment
-	file := os.ExpandEnv(s.Kubeconfig)
-	file = util.ResolveAbs(file)
-	return file
+	// expand the file path with system environment, the errors have been reported when the config is loaded
+	file, _ := util.ExpandEnv(s.Kubeconfig)
+	return util.ResolveAbs(file)
 }
 
 type Manifest struct {
@@ -142,14 +224,61 @@ type Run struct {
 	Waits   []Wait `yaml:"wait"`
 }
 
+// Wait is a condition of a setup step to be ready, it's the kubectl wait `for` condition in kind if the type is not set,
+// otherwise it's an environment-agnostic condition which works in both kind and compose.
 type Wait struct {
 	Namespace     string `yaml:"namespace"`
 	Resource      string `yaml:"resource"`
 	LabelSelector string `yaml:"label-selector"`
 	For           string `yaml:"for"`
+
+	// Type is the type of the environment-agnostic condition, http, tcp, command or log.
+	Type string `yaml:"type"`
+	// URL is requested by the http condition.
+	URL string `yaml:"url"`
+	// Status is the expected response status of the http condition, it's 200 by default.
+	Status int `yaml:"status"`
+	// Body is the regular expression matching the response body of the http condition.
+	Body string `yaml:"body"`
+	// Address is the host:port connected by the tcp condition.
+	Address string `yaml:"address"`
+	// Command is executed by the command condition, which should exit with 0.
+	Command string `yaml:"command"`
+	// Regex is the regular expression matching a line of the log of the `resource` in the log condition,
+	// the resource is the compose service name in compose, and the pod or its owner, such as deployment/oap, in kind.
+	Regex string `yaml:"regex"`
+	// Interval is the interval between two checks of the condition, it's 1s by default.
+	Interval string `yaml:"interval"`
+	// Timeout is the timeout of the condition, it's the remaining setup timeout by default.
+	Timeout string `yaml:"timeout"`
+}
+
+// GetInterval parses the interval of the condition.
+func (w *Wait) GetInterval() (time.Duration, error) {
+	if w.Interval == "" {
+		return constant.DefaultWaitInterval, nil
+	}
+	d, err := time.ParseDuration(w.Interval)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the interval of the %s wait: %v", w.Type, err)
+	}
+	return d, nil
+}
+
+// GetTimeout parses the timeout of the condition, zero means it's not set.
+func (w *Wait) GetTimeout() (time.Duration, error) {
+	if w.Timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(w.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the timeout of the %s wait: %v", w.Type, err)
+	}
+	return d, nil
 }
 
 type Trigger struct {
+	Name     string            `yaml:"name"`
 	Action   string            `yaml:"action"`
 	Interval string            `yaml:"interval"`
 	Times    int               `yaml:"times"`
@@ -157,6 +286,65 @@ type Trigger struct {
 	Method   string            `yaml:"method"`
 	Body     string            `yaml:"body"`
 	Headers  map[string]string `yaml:"headers"`
+	// Template enables rendering the body and headers of each request by template.
+	Template bool   `yaml:"template"`
+	Command  string `yaml:"command"`
+	// DescriptorSet is the protobuf descriptor set file used by the grpc action.
+	DescriptorSet string         `yaml:"descriptor-set"`
+	Load          *TriggerLoad   `yaml:"load"`
+	Expect        *TriggerExpect `yaml:"expect"`
+}
+
+// TriggerExpect configures the success criteria of the http trigger response.
+type TriggerExpect struct {
+	Status    []int             `yaml:"status"`
+	Body      string            `yaml:"body"`
+	JSONPath  string            `yaml:"json-path"`
+	JSONValue string            `yaml:"json-value"`
+	Headers   map[string]string `yaml:"headers"`
+}
+
+// TriggerLoad configures the rate based load mode of the http trigger.
+type TriggerLoad struct {
+	RPS         int    `yaml:"rps"`
+	Concurrency int    `yaml:"concurrency"`
+	RampUp      string `yaml:"ramp-up"`
+	Steady      string `yaml:"steady"`
+	RampDown    string `yaml:"ramp-down"`
+}
+
+// Triggers holds all the trigger actions, it could be configured as a single trigger,
+// or a list of named triggers which are executed concurrently.
+type Triggers []Trigger
+
+func (t *Triggers) UnmarshalYAML(unmarshal func(any) error) error {
+	var triggers []Trigger
+	if err := unmarshal(&triggers); err == nil {
+		*t = triggers
+		return nil
+	}
+
+	var single Trigger
+	if err := unmarshal(&single); err != nil {
+		return err
+	}
+	*t = Triggers{single}
+	return nil
+}
+
+// Finalize sets the default name of the triggers and makes sure the names are unique.
+func (t Triggers) Finalize() error {
+	names := make(map[string]bool, len(t))
+	for idx := range t {
+		if t[idx].Name == "" {
+			t[idx].Name = fmt.Sprintf("%s-%d", t[idx].Action, idx)
+		}
+		if names[t[idx].Name] {
+			return fmt.Errorf("duplicate trigger name: %s", t[idx].Name)
+		}
+		names[t[idx].Name] = true
+	}
+	return nil
 }
 
 type VerifyCase struct {
@@ -165,11 +353,12 @@ type VerifyCase struct {
 	Actual   string   `yaml:"actual"`
 	Expected string   `yaml:"expected"`
 	Includes []string `yaml:"includes"`
-}
-
-type VerifyRetryStrategy struct {
-	Count    int `yaml:"count"`
-	Interval any `yaml:"interval"`
+	// Tags are used to select the cases in command line, the included cases inherit the tags.
+	Tags []string `yaml:"tags"`
+	// Retry overrides the fields of the suite retry strategy it sets.
+	Retry *VerifyRetryStrategy `yaml:"retry"`
+	// Timeout is the hard timeout of the case, including all the attempts and the intervals.
+	Timeout string `yaml:"timeout"`
 }
 
 type ReusingCases struct {
@@ -185,27 +374,3 @@ func (v *VerifyCase) GetActual() string {
 func (v *VerifyCase) GetExpected() string {
 	return util.ResolveAbs(v.Expected)
 }
-
-// parseInterval parses a Duration field with number and string content for compatibility,
-// only use this when we previously allow configuring number like 120 and now string like 2m.
-// TODO remove this in 2.0
-func parseInterval(retryInterval any, name string) (time.Duration, error) {
-	var interval time.Duration
-	var err error
-	switch itv := retryInterval.(type) {
-	case int:
-		logger.Log.Warnf("configuring %v with number %v is deprecated and will be removed in future version,"+
-			" please use Duration style instead, such as 10s, 1m.", name, itv)
-		interval = time.Duration(itv) * time.Second
-	case string:
-		if interval, err = time.ParseDuration(itv); err != nil {
-			return 0, err
-		}
-	default:
-		return 0, fmt.Errorf("failed to parse %v: %v", name, retryInterval)
-	}
-	if interval < 0 {
-		interval = constant.DefaultWaitTimeout
-	}
-	return interval, nil
-}
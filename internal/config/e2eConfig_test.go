@@ -20,9 +20,11 @@ package config
 
 import (
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/apache/skywalking-infra-e2e/internal/util"
+	"gopkg.in/yaml.v2"
 	"k8s.io/apimachinery/pkg/util/rand"
 )
 
@@ -77,3 +79,80 @@ func TestSetup_GetFile(t *testing.T) {
 		})
 	}
 }
+
+func TestSetup_GetComposeFiles(t *testing.T) {
+	t.Setenv("SCENARIO", "cluster")
+	s := &Setup{
+		File:    "compose/base.yaml",
+		Compose: ComposeSetup{Files: []string{"compose/${SCENARIO}.yaml", "/abs/override.yaml"}},
+	}
+	want := []string{
+		util.ResolveAbs("compose/base.yaml"),
+		util.ResolveAbs("compose/cluster.yaml"),
+		"/abs/override.yaml",
+	}
+	if got := s.GetComposeFiles(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetComposeFiles() = %v, want %v", got, want)
+	}
+
+	s.File = ""
+	if got := s.GetComposeFiles(); !reflect.DeepEqual(got, want[1:]) {
+		t.Errorf("GetComposeFiles() without setup.file = %v, want %v", got, want[1:])
+	}
+}
+
+func TestTriggers_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Triggers
+		wantErr bool
+	}{{
+		name: "Should support single trigger",
+		content: `
+trigger:
+  action: http
+  url: http://localhost/`,
+		want: Triggers{{Name: "http-0", Action: "http", URL: "http://localhost/"}},
+	}, {
+		name: "Should support trigger list",
+		content: `
+trigger:
+  - name: provider
+    action: http
+    url: http://provider/
+  - action: cmd
+    command: echo foo`,
+		want: Triggers{
+			{Name: "provider", Action: "http", URL: "http://provider/"},
+			{Name: "cmd-1", Action: "cmd", Command: "echo foo"},
+		},
+	}, {
+		name: "Should fail with duplicate names",
+		content: `
+trigger:
+  - name: foo
+    action: http
+  - name: foo
+    action: cmd`,
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := &E2EConfig{}
+			if err := yaml.Unmarshal([]byte(tt.content), conf); err != nil {
+				t.Fatalf("unmarshal error: %v", err)
+			}
+			err := conf.Trigger.Finalize()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Triggers.Finalize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(conf.Trigger, tt.want) {
+				t.Errorf("Triggers = %+v, want %+v", conf.Trigger, tt.want)
+			}
+		})
+	}
+}
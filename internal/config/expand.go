@@ -0,0 +1,136 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+// unexpandedFields are the fields which are not expanded when the config is loaded, keyed by their paths in the config.
+var unexpandedFields = map[string]bool{
+	// the shell scripts are expanded by the shell itself, the request body and the regular expressions are kept as they are,
+	// since `$` is common in them.
+	"setup.steps.command":                 true,
+	"setup.steps.wait.command":            true,
+	"setup.steps.wait.body":               true,
+	"setup.steps.wait.regex":              true,
+	"setup.compose.services.wait.regex":   true,
+	"setup.compose.services.wait.command": true,
+	"trigger.command":                     true,
+	"trigger.body":                        true,
+	"trigger.expect.body":                 true,
+	"assert.cases.query":                  true,
+	"verify.cases.query":                  true,
+
+	// the fields are expanded in the phases using them, since the environment variables they reference may be only
+	// known after the previous phases: the steps could reference the variables exported by the previous steps,
+	// and the exposed ports and the triggers usually reference the ports exported by setup.
+	"setup.steps":             true,
+	"setup.kind.expose-ports": true,
+	"trigger":                 true,
+
+	// the fields are consumed or expanded by themselves before the config is expanded.
+	"extends":                       true,
+	"merge":                         true,
+	"vars":                          true,
+	"matrix":                        true,
+	"setup.init-system-environment": true,
+}
+
+// expandEnv expands the environment variables in the config when it's loaded, except the unexpanded fields.
+func (c *E2EConfig) expandEnv() error {
+	return expandEnv(reflect.ValueOf(c).Elem(), "")
+}
+
+// ExpandEnv expands the environment variables in the step right before it runs, the commands are expanded by the shell itself.
+func (s *Step) ExpandEnv() error {
+	return expandEnv(reflect.ValueOf(s).Elem(), "setup.steps")
+}
+
+// ExpandEnv expands the environment variables in the exposed port after all the setup steps finish.
+func (p *KindExposePort) ExpandEnv() error {
+	return expandEnv(reflect.ValueOf(p).Elem(), "setup.kind.expose-ports")
+}
+
+// ExpandEnv expands the environment variables in the triggers before they're created, the command is expanded by the shell itself.
+func (t Triggers) ExpandEnv() error {
+	return expandEnv(reflect.ValueOf(t), "trigger")
+}
+
+// expandEnv expands the environment variables in all the string fields of the value recursively,
+// the path is the yaml path of the value without list indices, and the unexpanded fields are skipped.
+func expandEnv(v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Kind() == reflect.Interface {
+			// the fields with number and string content for compatibility, see parseInterval.
+			if s, ok := v.Interface().(string); ok && v.CanSet() {
+				expanded, err := util.ExpandEnv(s)
+				if err != nil {
+					return fmt.Errorf("failed to expand %s: %v", path, err)
+				}
+				v.Set(reflect.ValueOf(expanded))
+			}
+			return nil
+		}
+		return expandEnv(v.Elem(), path)
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			if t.Field(i).PkgPath != "" {
+				continue
+			}
+			fieldPath := joinPath(path, yamlFieldName(t.Field(i)))
+			if unexpandedFields[fieldPath] {
+				continue
+			}
+			if err := expandEnv(v.Field(i), fieldPath); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice:
+		for i := 0; i < v.Len(); i++ {
+			if err := expandEnv(v.Index(i), path); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			if err := expandEnv(value, joinPath(path, fmt.Sprint(key))); err != nil {
+				return err
+			}
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		expanded, err := util.ExpandEnv(v.String())
+		if err != nil {
+			return fmt.Errorf("failed to expand %s: %v", path, err)
+		}
+		v.SetString(expanded)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("E2E_HOST", "127.0.0.1")
+	t.Setenv("E2E_IMAGE", "e2e:latest")
+
+	cfg := &E2EConfig{
+		Vars: map[string]string{"E2E_VAR": "$E2E_HOST"},
+		Setup: Setup{
+			File: "${E2E_DIR:-/tmp}/compose.yml",
+			Steps: []Step{{
+				Name:    "$E2E_HOST",
+				Command: "curl $E2E_HOST",
+				Waits:   []Wait{{Resource: "pod/$E2E_HOST"}, {Type: "http", URL: "http://$E2E_HOST/", Body: "^UP$"}},
+			}},
+			Kind: KindSetup{
+				ImportImages: []string{"$E2E_IMAGE"},
+				ExposePorts:  []KindExposePort{{Resource: "service/$E2E_HOST"}},
+			},
+		},
+		Trigger: Triggers{{URL: "http://${E2E_HOST}:8080"}},
+		Verify: Verify{
+			RetryStrategy: VerifyRetryStrategy{Interval: "${E2E_INTERVAL:-10s}"},
+			Cases:         []VerifyCase{{Query: "curl $E2E_HOST", Expected: "$E2E_HOST.yaml", Includes: []string{"${E2E_DIR:-/tmp}/cases.yaml"}}},
+		},
+	}
+	if err := cfg.expandEnv(); err != nil {
+		t.Fatalf("E2EConfig.expandEnv() error = %v", err)
+	}
+	want := &E2EConfig{
+		Vars: map[string]string{"E2E_VAR": "$E2E_HOST"},
+		Setup: Setup{
+			File: "/tmp/compose.yml",
+			Steps: []Step{{
+				Name:    "$E2E_HOST",
+				Command: "curl $E2E_HOST",
+				Waits:   []Wait{{Resource: "pod/$E2E_HOST"}, {Type: "http", URL: "http://$E2E_HOST/", Body: "^UP$"}},
+			}},
+			Kind: KindSetup{
+				ImportImages: []string{"e2e:latest"},
+				ExposePorts:  []KindExposePort{{Resource: "service/$E2E_HOST"}},
+			},
+		},
+		Trigger: Triggers{{URL: "http://${E2E_HOST}:8080"}},
+		Verify: Verify{
+			RetryStrategy: VerifyRetryStrategy{Interval: "10s"},
+			Cases:         []VerifyCase{{Query: "curl $E2E_HOST", Expected: "127.0.0.1.yaml", Includes: []string{"/tmp/cases.yaml"}}},
+		},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("E2EConfig.expandEnv() = %+v, want %+v", cfg, want)
+	}
+	setup := &cfg.Setup
+
+	step := &setup.Steps[0]
+	if err := step.ExpandEnv(); err != nil {
+		t.Fatalf("Step.ExpandEnv() error = %v", err)
+	}
+	wantStep := &Step{Name: "127.0.0.1", Command: "curl $E2E_HOST", Waits: []Wait{{Resource: "pod/127.0.0.1"}, {Type: "http", URL: "http://127.0.0.1/", Body: "^UP$"}}}
+	if !reflect.DeepEqual(step, wantStep) {
+		t.Errorf("Step.ExpandEnv() = %+v, want %+v", step, wantStep)
+	}
+
+	triggers := Triggers{{
+		URL:     "http://${E2E_HOST}:8080",
+		Body:    `{"query": "query ($id: ID!)"}`,
+		Headers: map[string]string{"Host": "$E2E_HOST"},
+		Expect:  &TriggerExpect{Body: "ok$"},
+	}}
+	if err := triggers.ExpandEnv(); err != nil {
+		t.Fatalf("Triggers.ExpandEnv() error = %v", err)
+	}
+	wantTriggers := Triggers{{
+		URL:     "http://127.0.0.1:8080",
+		Body:    `{"query": "query ($id: ID!)"}`,
+		Headers: map[string]string{"Host": "127.0.0.1"},
+		Expect:  &TriggerExpect{Body: "ok$"},
+	}}
+	if !reflect.DeepEqual(triggers, wantTriggers) {
+		t.Errorf("Triggers.ExpandEnv() = %+v, want %+v", triggers, wantTriggers)
+	}
+
+	if err := (&E2EConfig{Assert: Assert{Cases: []AssertCase{{Actual: "${E2E_ACTUAL:?actual is required}"}}}}).expandEnv(); err == nil {
+		t.Errorf("E2EConfig.expandEnv() should fail with the required variable unset")
+	}
+}
@@ -0,0 +1,181 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+const (
+	MergeAppend  = "append"
+	MergeReplace = "replace"
+)
+
+// pathFields are the fields holding file paths, they're resolved against the file defining them
+// when the file is extended by another one. The `[]` means every item of the list.
+var pathFields = [][]string{
+	{"setup", "file"},
+	{"setup", "kubeconfig"},
+	{"setup", "init-system-environment"},
+	{"setup", "compose", "files", "[]"},
+	{"setup", "steps", "[]", "path"},
+	{"trigger", "descriptor-set"},
+	{"trigger", "[]", "descriptor-set"},
+	{"verify", "cases", "[]", "expected"},
+	{"verify", "cases", "[]", "actual"},
+	{"verify", "cases", "[]", "includes", "[]"},
+	{"assert", "cases", "[]", "expected"},
+	{"assert", "cases", "[]", "actual"},
+	{"assert", "cases", "[]", "includes", "[]"},
+}
+
+// readConfigWithExtends reads the config file, and deep merges it over the base config file it extends recursively.
+func readConfigWithExtends(file string) ([]byte, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	merged, err := loadConfigWithExtends(abs, make(map[string]bool), false)
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(merged)
+}
+
+// loadConfigWithExtends loads the config file as a generic map, and deep merges it over the base config,
+// the relative paths of the extended files are resolved against the file defining them.
+func loadConfigWithExtends(file string, visited map[string]bool, extended bool) (map[any]any, error) {
+	if visited[file] {
+		return nil, fmt.Errorf("circular extends of e2e config file %s", file)
+	}
+	visited[file] = true
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read e2e config file %s error: %s", file, err)
+	}
+	current := make(map[any]any)
+	if err := yaml.Unmarshal(data, &current); err != nil {
+		return nil, fmt.Errorf("unmarshal e2e config file %s error: %s", file, err)
+	}
+	if extended {
+		for _, path := range pathFields {
+			resolvePathField(current, path, file)
+		}
+	}
+
+	extends, ok := current["extends"].(string)
+	if !ok && current["extends"] != nil {
+		return nil, fmt.Errorf("extends of e2e config file %s should be a file path", file)
+	}
+	modes, err := parseMergeModes(current["merge"], file)
+	if err != nil {
+		return nil, err
+	}
+	delete(current, "extends")
+	delete(current, "merge")
+	if extends == "" {
+		return current, nil
+	}
+
+	base, err := loadConfigWithExtends(util.ResolveAbsWithBase(extends, file), visited, true)
+	if err != nil {
+		return nil, err
+	}
+	return mergeConfig(base, current, "", modes).(map[any]any), nil
+}
+
+func parseMergeModes(merge any, file string) (map[string]string, error) {
+	if merge == nil {
+		return nil, nil
+	}
+	m, ok := merge.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("merge of e2e config file %s should be a mapping", file)
+	}
+	modes := make(map[string]string, len(m))
+	for path, mode := range m {
+		if mode != MergeAppend && mode != MergeReplace {
+			return nil, fmt.Errorf("merge mode of %v in e2e config file %s should be %s or %s, but was %v",
+				path, file, MergeAppend, MergeReplace, mode)
+		}
+		modes[fmt.Sprint(path)] = mode.(string)
+	}
+	return modes, nil
+}
+
+// mergeConfig deep merges the override value over the base value, the mappings are merged recursively,
+// the lists are replaced unless the merge mode of the path is append, and the other values are replaced.
+func mergeConfig(base, override any, path string, modes map[string]string) any {
+	switch o := override.(type) {
+	case map[any]any:
+		b, ok := base.(map[any]any)
+		if !ok {
+			return o
+		}
+		merged := make(map[any]any, len(b)+len(o))
+		for k, v := range b {
+			merged[k] = v
+		}
+		for k, v := range o {
+			merged[k] = mergeConfig(b[k], v, joinPath(path, fmt.Sprint(k)), modes)
+		}
+		return merged
+	case []any:
+		if b, ok := base.([]any); ok && modes[path] == MergeAppend {
+			return append(append([]any{}, b...), o...)
+		}
+		return o
+	default:
+		return o
+	}
+}
+
+// resolvePathField resolves the relative path of the field against the file, the paths starting with
+// environment variables are kept as they are, since they're usually absolute paths after expanded.
+func resolvePathField(node any, path []string, file string) any {
+	if len(path) == 0 {
+		p, ok := node.(string)
+		if !ok || p == "" || strings.HasPrefix(p, "$") {
+			return node
+		}
+		return util.ResolveAbsWithBase(p, file)
+	}
+
+	switch n := node.(type) {
+	case map[any]any:
+		if v, ok := n[path[0]]; ok {
+			n[path[0]] = resolvePathField(v, path[1:], file)
+		}
+	case []any:
+		if path[0] == "[]" {
+			for idx := range n {
+				n[idx] = resolvePathField(n[idx], path[1:], file)
+			}
+		}
+	}
+	return node
+}
@@ -0,0 +1,123 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestReadConfigWithExtends(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "base"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"base/e2e.yaml": `setup:
+  env: compose
+  file: docker-compose.yml
+  timeout: 20m
+  steps:
+    - name: install
+      command: echo install
+verify:
+  retry:
+    count: 10
+  cases:
+    - query: echo base
+      expected: expected/base.yaml
+`,
+		"e2e.yaml": `extends: base/e2e.yaml
+merge:
+  setup.steps: append
+setup:
+  file: docker-compose.override.yml
+  steps:
+    - name: init
+      command: echo init
+verify:
+  cases:
+    - query: echo current
+      expected: expected/current.yaml
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := readConfigWithExtends(filepath.Join(dir, "e2e.yaml"))
+	if err != nil {
+		t.Fatalf("readConfigWithExtends() error = %v", err)
+	}
+	got := E2EConfig{}
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	want := E2EConfig{
+		Setup: Setup{
+			Env:     "compose",
+			File:    "docker-compose.override.yml",
+			Timeout: "20m",
+			Steps: []Step{
+				{Name: "install", Command: "echo install"},
+				{Name: "init", Command: "echo init"},
+			},
+		},
+		Verify: Verify{
+			RetryStrategy: VerifyRetryStrategy{Count: 10},
+			Cases:         []VerifyCase{{Query: "echo current", Expected: "expected/current.yaml"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readConfigWithExtends() = %+v, want %+v", got, want)
+	}
+
+	// the paths in the base config are resolved against the base file.
+	if err := os.WriteFile(filepath.Join(dir, "e2e.yaml"), []byte("extends: base/e2e.yaml\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if data, err = readConfigWithExtends(filepath.Join(dir, "e2e.yaml")); err != nil {
+		t.Fatalf("readConfigWithExtends() error = %v", err)
+	}
+	got = E2EConfig{}
+	if err := yaml.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(dir, "base", "docker-compose.yml"); got.Setup.File != want {
+		t.Errorf("setup.file = %s, want %s", got.Setup.File, want)
+	}
+	if want := filepath.Join(dir, "base", "expected", "base.yaml"); got.Verify.Cases[0].Expected != want {
+		t.Errorf("verify.cases[0].expected = %s, want %s", got.Verify.Cases[0].Expected, want)
+	}
+
+	// circular extends.
+	if err := os.WriteFile(filepath.Join(dir, "base", "e2e.yaml"), []byte("extends: ../e2e.yaml\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := readConfigWithExtends(filepath.Join(dir, "e2e.yaml")); err == nil {
+		t.Errorf("readConfigWithExtends() should fail with circular extends")
+	}
+}
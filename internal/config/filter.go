@@ -0,0 +1,68 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import "path"
+
+// GlobalCaseFilter is the filter of the verify and assert cases specified in command line.
+var GlobalCaseFilter CaseFilter
+
+// CaseFilter selects the cases by their names and tags, the cases not selected are skipped.
+type CaseFilter struct {
+	// Names are the names or the glob patterns of the names of the selected cases.
+	Names []string
+	// Tags selects the cases with any of the tags.
+	Tags []string
+	// ExcludeTags excludes the cases with any of the tags.
+	ExcludeTags []string
+}
+
+// Match returns whether the case with the name and tags is selected.
+func (f *CaseFilter) Match(name string, tags []string) bool {
+	if len(f.Names) > 0 && !matchAny(f.Names, name) {
+		return false
+	}
+	if len(f.Tags) > 0 && !containsAny(f.Tags, tags) {
+		return false
+	}
+	return !containsAny(f.ExcludeTags, tags)
+}
+
+func matchAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if pattern == name {
+			return true
+		}
+		if matched, err := path.Match(pattern, name); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(expected, actual []string) bool {
+	for _, e := range expected {
+		for _, a := range actual {
+			if e == a {
+				return true
+			}
+		}
+	}
+	return false
+}
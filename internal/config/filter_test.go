@@ -0,0 +1,79 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestCaseFilter_Match(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter CaseFilter
+		cases  map[string][]string
+		want   []string
+	}{
+		{
+			name:   "no filter",
+			filter: CaseFilter{},
+			cases:  map[string][]string{"service": nil, "topology": {"slow"}},
+			want:   []string{"service", "topology"},
+		},
+		{
+			name:   "names and globs",
+			filter: CaseFilter{Names: []string{"service", "endpoint-*"}},
+			cases:  map[string][]string{"service": nil, "endpoint-list": nil, "topology": nil},
+			want:   []string{"endpoint-list", "service"},
+		},
+		{
+			name:   "tags",
+			filter: CaseFilter{Tags: []string{"smoke", "trace"}},
+			cases:  map[string][]string{"service": {"smoke"}, "trace": {"trace", "slow"}, "topology": {"slow"}, "log": nil},
+			want:   []string{"service", "trace"},
+		},
+		{
+			name:   "exclude tags",
+			filter: CaseFilter{Tags: []string{"smoke"}, ExcludeTags: []string{"slow"}},
+			cases:  map[string][]string{"service": {"smoke"}, "topology": {"smoke", "slow"}},
+			want:   []string{"service"},
+		},
+		{
+			name:   "names and tags",
+			filter: CaseFilter{Names: []string{"*-list"}, ExcludeTags: []string{"slow"}},
+			cases:  map[string][]string{"service-list": nil, "endpoint-list": {"slow"}, "service": nil},
+			want:   []string{"service-list"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for name, tags := range tt.cases {
+				if tt.filter.Match(name, tags) {
+					got = append(got, name)
+				}
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Match() selected %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
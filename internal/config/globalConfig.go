@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 
 	"github.com/apache/skywalking-infra-e2e/internal/constant"
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
@@ -39,29 +40,75 @@ type GlobalE2EConfig struct {
 var GlobalConfig GlobalE2EConfig
 
 func init() {
+	GlobalConfig.E2EConfig = defaultE2EConfig()
+}
+
+func defaultE2EConfig() E2EConfig {
+	cfg := E2EConfig{}
 	if os.Getenv("CI") == "true" {
-		GlobalConfig.E2EConfig.Cleanup.On = constant.CleanUpAlways
+		cfg.Cleanup.On = constant.CleanUpAlways
 	} else {
-		GlobalConfig.E2EConfig.Cleanup.On = constant.CleanUpOnSuccess
+		cfg.Cleanup.On = constant.CleanUpOnSuccess
 	}
 
-	GlobalConfig.E2EConfig.Verify.FailFast = true
+	cfg.Verify.FailFast = true
+	return cfg
 }
 
+// loadE2EConfig reads the config file with the files it extends, and applies the overrides, the config is not expanded.
+func loadE2EConfig(file string) (E2EConfig, error) {
+	cfg := defaultE2EConfig()
+	data, err := readConfigWithExtends(file)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("unmarshal e2e config file %s error: %s", file, err)
+	}
+
+	// the overrides are applied to the config as written, so the indices of the cases don't count the included cases.
+	if err := applyOverrides(&cfg, util.Overrides); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+// ReadGlobalConfigFile reads the config file into the GlobalConfig, it could be called again to reload the config,
+// such as after the matrix values are exported, since the config is expanded in place.
 func ReadGlobalConfigFile() {
+	GlobalConfig.E2EConfig = defaultE2EConfig()
 	if !util.PathExist(util.CfgFile) {
 		GlobalConfig.Error = fmt.Errorf("e2e config file %s not exist", util.CfgFile)
 		return
 	}
 
-	data, err := os.ReadFile(util.CfgFile)
+	cfg, err := loadE2EConfig(util.CfgFile)
 	if err != nil {
-		GlobalConfig.Error = fmt.Errorf("read e2e config file %s error: %s", util.CfgFile, err)
+		GlobalConfig.Error = err
+		return
+	}
+	GlobalConfig.E2EConfig = cfg
+
+	if err := GlobalConfig.E2EConfig.Matrix.exportDefaults(); err != nil {
+		GlobalConfig.Error = err
 		return
 	}
 
-	if err := yaml.Unmarshal(data, &GlobalConfig.E2EConfig); err != nil {
-		GlobalConfig.Error = fmt.Errorf("unmarshal e2e config file %s error: %s", util.CfgFile, err)
+	if err := exportVars(GlobalConfig.E2EConfig.Vars); err != nil {
+		GlobalConfig.Error = err
+		return
+	}
+
+	// the variables in the init system environment file could be referenced by the config.
+	if err := exportInitSystemEnvironment(&GlobalConfig.E2EConfig.Setup); err != nil {
+		GlobalConfig.Error = err
+		return
+	}
+
+	// expand the config before the files of the cases are resolved, so they could be absolute paths in variables.
+	if err := GlobalConfig.E2EConfig.expandEnv(); err != nil {
+		GlobalConfig.Error = err
 		return
 	}
 
@@ -71,45 +118,114 @@ func ReadGlobalConfigFile() {
 		return
 	}
 
+	// convert assert
+	if err := convertAssert(&GlobalConfig.E2EConfig.Assert); err != nil {
+		GlobalConfig.Error = err
+		return
+	}
+
+	if err := GlobalConfig.E2EConfig.Trigger.Finalize(); err != nil {
+		GlobalConfig.Error = err
+		return
+	}
+
 	if err := GlobalConfig.E2EConfig.Setup.Finalize(); err != nil {
 		GlobalConfig.Error = err
+		return
 	}
 
 	GlobalConfig.Error = nil
 	logger.Log.Info("load the e2e config successfully")
 }
 
+func exportInitSystemEnvironment(setup *Setup) error {
+	if setup.InitSystemEnvironment == "" {
+		return nil
+	}
+	profile, err := util.ExpandEnv(setup.InitSystemEnvironment)
+	if err != nil {
+		return fmt.Errorf("failed to expand setup.init-system-environment: %v", err)
+	}
+	setup.InitSystemEnvironment = util.ResolveAbs(profile)
+	util.ExportEnvVars(setup.InitSystemEnvironment)
+	return nil
+}
+
 func convertVerify(verify *Verify) error {
-	// convert cases
-	result := make([]VerifyCase, 0)
 	cfgAbsPath, _ := filepath.Abs(util.CfgFile)
-	for idx := range verify.Cases {
-		cases, err := convertSingleCase(&verify.Cases[idx], cfgAbsPath)
+	cases, err := convertCases[VerifyCase](verify.Cases, cfgAbsPath, "verify")
+	if err != nil {
+		return err
+	}
+	verify.Cases = cases
+	return nil
+}
+
+func convertAssert(assert *Assert) error {
+	cfgAbsPath, _ := filepath.Abs(util.CfgFile)
+	cases, err := convertCases[AssertCase](assert.Cases, cfgAbsPath, "assert")
+	if err != nil {
+		return err
+	}
+	assert.Cases = cases
+	return nil
+}
+
+// reusableCase is the case which could include the cases in other files, such as *VerifyCase and *AssertCase.
+type reusableCase[T any] interface {
+	*T
+	includes() []string
+	query() string
+	expected() *string
+	actual() *string
+	tags() []string
+	inheritTags(tags []string)
+}
+
+func (v *VerifyCase) includes() []string        { return v.Includes }
+func (v *VerifyCase) query() string             { return v.Query }
+func (v *VerifyCase) expected() *string         { return &v.Expected }
+func (v *VerifyCase) actual() *string           { return &v.Actual }
+func (v *VerifyCase) tags() []string            { return v.Tags }
+func (v *VerifyCase) inheritTags(tags []string) { v.Tags = append(v.Tags, tags...) }
+
+func (v *AssertCase) includes() []string        { return v.Includes }
+func (v *AssertCase) query() string             { return v.Query }
+func (v *AssertCase) expected() *string         { return &v.Expected }
+func (v *AssertCase) actual() *string           { return &v.Actual }
+func (v *AssertCase) tags() []string            { return v.Tags }
+func (v *AssertCase) inheritTags(tags []string) { v.Tags = append(v.Tags, tags...) }
+
+// convertCases expands the included cases, and resolves the files of the cases against the file they are defined in,
+// the section is the path of the cases in the config, which is used to expand the included cases.
+func convertCases[T any, P reusableCase[T]](cases []T, baseFile, section string) ([]T, error) {
+	result := make([]T, 0)
+	for idx := range cases {
+		converted, err := convertSingleCase[T, P](&cases[idx], baseFile, section)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		result = append(result, cases...)
+		result = append(result, converted...)
 	}
-	verify.Cases = result
-	return nil
+	return result, nil
 }
 
-func convertSingleCase(verifyCase *VerifyCase, baseFile string) ([]VerifyCase, error) {
-	if len(verifyCase.Includes) > 0 && (verifyCase.Expected != "" || verifyCase.Query != "") {
+func convertSingleCase[T any, P reusableCase[T]](c *T, baseFile, section string) ([]T, error) {
+	reusable := P(c)
+	if len(reusable.includes()) > 0 && (*reusable.expected() != "" || reusable.query() != "") {
 		return nil, fmt.Errorf("include and query/expected only support selecting one of them in a case")
 	}
-	if len(verifyCase.Includes) == 0 {
+	if len(reusable.includes()) == 0 {
 		// using base path to resolve case paths
-		if verifyCase.Expected != "" {
-			verifyCase.Expected = util.ResolveAbsWithBase(verifyCase.Expected, baseFile)
-		}
-		if verifyCase.Actual != "" {
-			verifyCase.Actual = util.ResolveAbsWithBase(verifyCase.Actual, baseFile)
+		for _, file := range []*string{reusable.expected(), reusable.actual()} {
+			if *file != "" {
+				*file = util.ResolveAbsWithBase(*file, baseFile)
+			}
 		}
-		return []VerifyCase{*verifyCase}, nil
+		return []T{*c}, nil
 	}
-	result := make([]VerifyCase, 0)
-	for _, include := range verifyCase.Includes {
+	result := make([]T, 0)
+	for _, include := range reusable.includes() {
 		includePath := util.ResolveAbsWithBase(include, baseFile)
 
 		if !util.PathExist(includePath) {
@@ -121,19 +237,25 @@ func convertSingleCase(verifyCase *VerifyCase, baseFile string) ([]VerifyCase, e
 			return nil, fmt.Errorf("reuse case config file %s error: %s", includePath, err)
 		}
 
-		r := &ReusingCases{}
+		r := &struct {
+			Cases []T `yaml:"cases"`
+		}{}
 		if err := yaml.Unmarshal(data, r); err != nil {
 			return nil, fmt.Errorf("unmarshal reuse case config file %s error: %s", includePath, err)
 		}
+		if err := expandEnv(reflect.ValueOf(r).Elem(), section); err != nil {
+			return nil, fmt.Errorf("reuse case config file %s error: %s", includePath, err)
+		}
 
-		for idx := range r.Cases {
-			// using include file path as base path to resolve cases
-			cases, err := convertSingleCase(&r.Cases[idx], includePath)
-			if err != nil {
-				return nil, err
-			}
-			result = append(result, cases...)
+		// using include file path as base path to resolve cases
+		cases, err := convertCases[T, P](r.Cases, includePath, section)
+		if err != nil {
+			return nil, err
 		}
+		for i := range cases {
+			P(&cases[i]).inheritTags(reusable.tags())
+		}
+		result = append(result, cases...)
 	}
 	return result, nil
 }
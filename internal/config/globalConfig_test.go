@@ -0,0 +1,177 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+func TestConvertCases(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "shared", "nested"), os.ModePerm); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"shared/cases.yaml": `cases:
+  - query: echo trace
+    expected: expected/trace.yaml
+  - includes:
+      - nested/cases.yaml
+`,
+		"shared/nested/cases.yaml": `cases:
+  - actual: actual.yaml
+    expected: /abs/expected.yaml
+    tags: [slow]
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	base := filepath.Join(dir, "e2e.yaml")
+	got, err := convertCases[AssertCase]([]AssertCase{{Includes: []string{"shared/cases.yaml"}, Tags: []string{"trace"}}}, base, "assert")
+	if err != nil {
+		t.Fatalf("convertCases() error = %v", err)
+	}
+	want := []AssertCase{
+		{Query: "echo trace", Expected: filepath.Join(dir, "shared", "expected", "trace.yaml"), Tags: []string{"trace"}},
+		{Actual: filepath.Join(dir, "shared", "nested", "actual.yaml"), Expected: "/abs/expected.yaml", Tags: []string{"slow", "trace"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("convertCases() = %+v, want %+v", got, want)
+	}
+
+	if _, err := convertCases[AssertCase]([]AssertCase{{Query: "echo", Includes: []string{"shared/cases.yaml"}}}, base, "assert"); err == nil {
+		t.Errorf("convertCases() should fail if both includes and query are specified")
+	}
+}
+
+func TestReadGlobalConfigFile_OverridesBeforeIncludes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"e2e.yaml": `verify:
+  cases:
+    - includes:
+        - cases.yaml
+    - query: echo service
+      expected: expected/service.yaml
+`,
+		"cases.yaml": `cases:
+  - query: echo endpoint
+    expected: expected/endpoint.yaml
+  - query: echo instance
+    expected: expected/instance.yaml
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfgFile, overrides, globalConfig := util.CfgFile, util.Overrides, GlobalConfig
+	defer func() { util.CfgFile, util.Overrides, GlobalConfig = cfgFile, overrides, globalConfig }()
+	util.CfgFile = filepath.Join(dir, "e2e.yaml")
+	util.Overrides = []string{"verify.cases[1].expected=expected/overridden.yaml"}
+
+	ReadGlobalConfigFile()
+	if GlobalConfig.Error != nil {
+		t.Fatalf("ReadGlobalConfigFile() error = %v", GlobalConfig.Error)
+	}
+	var got []string
+	for _, c := range GlobalConfig.E2EConfig.Verify.Cases {
+		got = append(got, c.Expected)
+	}
+	// the index 1 is the second case in the config file, not the second one after the includes are expanded.
+	want := []string{
+		filepath.Join(dir, "expected", "endpoint.yaml"),
+		filepath.Join(dir, "expected", "instance.yaml"),
+		filepath.Join(dir, "expected", "overridden.yaml"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the expected files = %v, want %v", got, want)
+	}
+}
+
+func TestReadGlobalConfigFile_ExpandBeforeResolve(t *testing.T) {
+	dir := t.TempDir()
+	shared := t.TempDir()
+	t.Setenv("E2E_SHARED", shared)
+	files := map[string]string{
+		filepath.Join(dir, "e2e.yaml"): `vars:
+  E2E_EXPECTED: ${E2E_SHARED}/expected
+verify:
+  cases:
+    - query: echo service
+      expected: ${E2E_EXPECTED}/service.yaml
+    - includes:
+        - ${E2E_SHARED}/cases.yaml
+`,
+		filepath.Join(shared, "cases.yaml"): `cases:
+  - query: echo endpoint
+    expected: ${E2E_CASE:-endpoint}.yaml
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfgFile, overrides, globalConfig := util.CfgFile, util.Overrides, GlobalConfig
+	defer func() { util.CfgFile, util.Overrides, GlobalConfig = cfgFile, overrides, globalConfig }()
+	util.CfgFile, util.Overrides = filepath.Join(dir, "e2e.yaml"), nil
+	t.Setenv("E2E_EXPECTED", "")
+
+	ReadGlobalConfigFile()
+	if GlobalConfig.Error != nil {
+		t.Fatalf("ReadGlobalConfigFile() error = %v", GlobalConfig.Error)
+	}
+	var got []string
+	for _, c := range GlobalConfig.E2EConfig.Verify.Cases {
+		got = append(got, c.Expected)
+	}
+	want := []string{filepath.Join(shared, "expected", "service.yaml"), filepath.Join(shared, "endpoint.yaml")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("the expected files = %v, want %v", got, want)
+	}
+}
+
+func TestReadGlobalConfigFile_InvalidSetupTimeout(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "e2e.yaml"), []byte("setup:\n  timeout: soon\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfgFile, overrides, globalConfig := util.CfgFile, util.Overrides, GlobalConfig
+	defer func() { util.CfgFile, util.Overrides, GlobalConfig = cfgFile, overrides, globalConfig }()
+	util.CfgFile, util.Overrides = filepath.Join(dir, "e2e.yaml"), nil
+
+	ReadGlobalConfigFile()
+	if GlobalConfig.Error == nil {
+		t.Errorf("ReadGlobalConfigFile() should fail if setup.timeout is invalid")
+	}
+}
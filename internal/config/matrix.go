@@ -0,0 +1,138 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+// unsafeIDChars matches the characters which are not allowed in the names of the compose projects and kind clusters.
+var unsafeIDChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Matrix is the values of the variables, the e2e test runs once per combination of them.
+type Matrix map[string][]string
+
+// Combination is a combination of the matrix values.
+type Combination struct {
+	// Name is the readable name of the combination, such as `STORAGE=es,VERSION=8`.
+	Name string
+	// ID identifies the combination in the names of the resources, such as the compose projects and the kind clusters.
+	ID     string
+	Values map[string]string
+}
+
+// Combinations returns all the combinations of the matrix values, the variables are ordered by their names.
+func (m Matrix) Combinations() []*Combination {
+	if len(m) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	combinations := []map[string]string{{}}
+	for _, name := range names {
+		next := make([]map[string]string, 0, len(combinations)*len(m[name]))
+		for _, c := range combinations {
+			for _, value := range m[name] {
+				values := make(map[string]string, len(c)+1)
+				for k, v := range c {
+					values[k] = v
+				}
+				values[name] = value
+				next = append(next, values)
+			}
+		}
+		combinations = next
+	}
+
+	result := make([]*Combination, 0, len(combinations))
+	ids := make(map[string]bool, len(combinations))
+	for idx, values := range combinations {
+		pairs, parts := make([]string, 0, len(names)), make([]string, 0, len(names))
+		for _, name := range names {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", name, values[name]))
+			if part := strings.Trim(unsafeIDChars.ReplaceAllString(strings.ToLower(values[name]), "-"), "-"); part != "" {
+				parts = append(parts, part)
+			}
+		}
+		id := strings.Join(parts, "-")
+		if id == "" || ids[id] {
+			id = strings.Trim(fmt.Sprintf("%s-%d", id, idx+1), "-")
+		}
+		ids[id] = true
+		result = append(result, &Combination{Name: strings.Join(pairs, ","), ID: id, Values: values})
+	}
+	return result
+}
+
+// Export exports the values of the combination as environment variables.
+func (c *Combination) Export() error {
+	for name, value := range c.Values {
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to export matrix variable %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// exportDefaults exports the first values of the matrix variables which are not set,
+// so the commands other than `e2e run` work with the first combination unless the variables are set.
+func (m Matrix) exportDefaults() error {
+	for name, values := range m {
+		if _, ok := os.LookupEnv(name); ok || len(values) == 0 {
+			continue
+		}
+		if err := os.Setenv(name, values[0]); err != nil {
+			return fmt.Errorf("failed to export matrix variable %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// exportVars expands and exports the variables in the order of their names,
+// so a variable could reference the matrix values, the environment variables and the variables before it.
+func exportVars(vars map[string]string) error {
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, err := util.ExpandEnv(vars[name])
+		if err != nil {
+			return fmt.Errorf("failed to expand variable %s: %v", name, err)
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return fmt.Errorf("failed to export variable %s: %v", name, err)
+		}
+		logger.Log.Debugf("export %s=%s", name, value)
+	}
+	return nil
+}
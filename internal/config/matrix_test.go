@@ -0,0 +1,77 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestMatrixCombinations(t *testing.T) {
+	if combinations := (Matrix{}).Combinations(); combinations != nil {
+		t.Errorf("Combinations() of empty matrix = %v, want nil", combinations)
+	}
+
+	matrix := Matrix{
+		"VERSION": {"8.0", "9.1"},
+		"STORAGE": {"h2", "ElasticSearch"},
+	}
+	var got []Combination
+	for _, c := range matrix.Combinations() {
+		got = append(got, *c)
+	}
+	want := []Combination{
+		{Name: "STORAGE=h2,VERSION=8.0", ID: "h2-8-0", Values: map[string]string{"STORAGE": "h2", "VERSION": "8.0"}},
+		{Name: "STORAGE=h2,VERSION=9.1", ID: "h2-9-1", Values: map[string]string{"STORAGE": "h2", "VERSION": "9.1"}},
+		{Name: "STORAGE=ElasticSearch,VERSION=8.0", ID: "elasticsearch-8-0", Values: map[string]string{"STORAGE": "ElasticSearch", "VERSION": "8.0"}},
+		{Name: "STORAGE=ElasticSearch,VERSION=9.1", ID: "elasticsearch-9-1", Values: map[string]string{"STORAGE": "ElasticSearch", "VERSION": "9.1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Combinations() = %+v, want %+v", got, want)
+	}
+
+	duplicated := Matrix{"TAG": {"v1.0", "v1-0", "+"}}.Combinations()
+	for idx, id := range []string{"v1-0", "v1-0-2", "3"} {
+		if duplicated[idx].ID != id {
+			t.Errorf("Combinations()[%d].ID = %s, want %s", idx, duplicated[idx].ID, id)
+		}
+	}
+}
+
+func TestExportVars(t *testing.T) {
+	t.Setenv("E2E_STORAGE", "es")
+	t.Setenv("E2E_ES_HOST", "")
+	t.Setenv("E2E_STORAGE_URL", "")
+
+	err := exportVars(map[string]string{
+		"E2E_STORAGE_URL": "http://${E2E_ES_HOST}:9200/$E2E_STORAGE",
+		"E2E_ES_HOST":     "${E2E_HOST:-localhost}",
+	})
+	if err != nil {
+		t.Fatalf("exportVars() error = %v", err)
+	}
+	if got := os.Getenv("E2E_STORAGE_URL"); got != "http://localhost:9200/es" {
+		t.Errorf("E2E_STORAGE_URL = %s, want http://localhost:9200/es", got)
+	}
+
+	if err := exportVars(map[string]string{"E2E_STORAGE_URL": "${E2E_HOST:?}"}); err == nil {
+		t.Errorf("exportVars() should fail with the required variable unset")
+	}
+}
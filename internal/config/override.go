@@ -0,0 +1,150 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	// overrideSegment matches a segment of the override path, such as `cases` or `cases[2]`.
+	overrideSegment = regexp.MustCompile(`^([^\[\]]+)((?:\[\d+\])*)$`)
+	overrideIndex   = regexp.MustCompile(`\d+`)
+)
+
+// overrideToken is a field name, a map key or a list index in the override path.
+type overrideToken struct {
+	name  string
+	index int
+}
+
+// applyOverrides applies the overrides in the format of `path.to.key=value` to the config,
+// the fields are named by their yaml tags, and the list items are referenced by indices, such as `verify.cases[2].expected`.
+func applyOverrides(cfg *E2EConfig, overrides []string) error {
+	for _, override := range overrides {
+		path, value, ok := strings.Cut(override, "=")
+		if !ok || path == "" {
+			return fmt.Errorf("the override should be in the format of path.to.key=value, but was %s", override)
+		}
+		tokens, err := parseOverridePath(path)
+		if err != nil {
+			return err
+		}
+		if err := applyOverride(reflect.ValueOf(cfg).Elem(), tokens, value); err != nil {
+			return fmt.Errorf("failed to override %s: %v", path, err)
+		}
+	}
+	return nil
+}
+
+func parseOverridePath(path string) ([]overrideToken, error) {
+	var tokens []overrideToken
+	for _, segment := range strings.Split(path, ".") {
+		matches := overrideSegment.FindStringSubmatch(segment)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid override path %s", path)
+		}
+		tokens = append(tokens, overrideToken{name: matches[1]})
+		for _, index := range overrideIndex.FindAllString(matches[2], -1) {
+			i, _ := strconv.Atoi(index)
+			tokens = append(tokens, overrideToken{index: i})
+		}
+	}
+	return tokens, nil
+}
+
+func applyOverride(v reflect.Value, tokens []overrideToken, value string) error {
+	for idx := 0; idx < len(tokens); idx++ {
+		token := tokens[idx]
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		switch {
+		case token.name == "" && v.Kind() == reflect.Slice:
+			if token.index >= v.Len() {
+				return fmt.Errorf("index %d out of range, there are %d item(s)", token.index, v.Len())
+			}
+			v = v.Index(token.index)
+		case token.name == "":
+			return fmt.Errorf("index %d on a non-list field", token.index)
+		case v.Kind() == reflect.Struct:
+			field, ok := yamlFieldByName(v, token.name)
+			if !ok {
+				return fmt.Errorf("unknown field %s", token.name)
+			}
+			v = field
+		case v.Kind() == reflect.Map && idx == len(tokens)-1:
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			item := reflect.New(v.Type().Elem()).Elem()
+			if err := setOverrideValue(item, value); err != nil {
+				return err
+			}
+			v.SetMapIndex(reflect.ValueOf(token.name).Convert(v.Type().Key()), item)
+			return nil
+		case v.Type() == reflect.TypeOf(Triggers{}) && v.Len() == 1:
+			// the single trigger could be overridden without index.
+			v = v.Index(0)
+			idx--
+		default:
+			return fmt.Errorf("field %s not found", token.name)
+		}
+	}
+	return setOverrideValue(v, value)
+}
+
+// setOverrideValue sets the value to the field, the value is parsed as yaml unless the field is string.
+func setOverrideValue(v reflect.Value, value string) error {
+	if v.Kind() == reflect.String {
+		v.SetString(value)
+		return nil
+	}
+	parsed := reflect.New(v.Type())
+	if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return fmt.Errorf("failed to parse the value %s as %s: %v", value, v.Type(), err)
+	}
+	v.Set(parsed.Elem())
+	return nil
+}
+
+// yamlFieldByName returns the field of the struct by the yaml name.
+func yamlFieldByName(v reflect.Value, name string) (reflect.Value, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+		if yamlFieldName(f) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
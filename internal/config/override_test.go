@@ -0,0 +1,77 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyOverrides(t *testing.T) {
+	cfg := &E2EConfig{
+		Trigger: Triggers{{Action: "http", Times: 5}},
+		Verify: Verify{
+			Cases: []VerifyCase{{Query: "echo 0"}, {Query: "echo 1"}},
+		},
+	}
+	err := applyOverrides(cfg, []string{
+		"setup.timeout=30m",
+		"cleanup.on=never",
+		"trigger.times=10",
+		"trigger[0].headers.X-Token=a=b",
+		"trigger[0].load.rps=100",
+		"verify.retry.count=3",
+		"verify.retry.interval=10",
+		"verify.fail-fast=false",
+		"verify.cases[1].expected=expected.yaml",
+	})
+	if err != nil {
+		t.Fatalf("applyOverrides() error = %v", err)
+	}
+
+	want := &E2EConfig{
+		Setup:   Setup{Timeout: "30m"},
+		Cleanup: Cleanup{On: "never"},
+		Trigger: Triggers{{
+			Action:  "http",
+			Times:   10,
+			Headers: map[string]string{"X-Token": "a=b"},
+			Load:    &TriggerLoad{RPS: 100},
+		}},
+		Verify: Verify{
+			RetryStrategy: VerifyRetryStrategy{Count: 3, Interval: 10},
+			Cases:         []VerifyCase{{Query: "echo 0"}, {Query: "echo 1", Expected: "expected.yaml"}},
+		},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("applyOverrides() = %+v, want %+v", cfg, want)
+	}
+
+	for _, override := range []string{
+		"verify.cases[2].expected=expected.yaml",
+		"verify.unknown=1",
+		"verify.retry.count=ten",
+		"setup.env[0]=kind",
+		"verify.cases",
+	} {
+		if err := applyOverrides(cfg, []string{override}); err == nil {
+			t.Errorf("applyOverrides(%s) should fail", override)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"strconv"
+	"time"
+
+	"github.com/apache/skywalking-infra-e2e/internal/logger"
+)
+
+const (
+	BackoffFixed       = "fixed"
+	BackoffExponential = "exponential"
+
+	defaultRetryInterval = time.Second
+)
+
+type VerifyRetryStrategy struct {
+	Count    int `yaml:"count"`
+	Interval any `yaml:"interval"`
+	// Backoff is the way the interval grows, `fixed`(default) or `exponential`, the latter doubles the interval after each retry.
+	Backoff string `yaml:"backoff"`
+	// MaxInterval caps the interval growing exponentially.
+	MaxInterval string `yaml:"max-interval"`
+	// Jitter is the ratio of the random deviation of each interval, such as 0.2 for ±20%.
+	Jitter float64 `yaml:"jitter"`
+	// Deadline is the total time of retrying a case, the case is retried until it passes or the deadline is reached,
+	// and the count is unlimited unless it's set.
+	Deadline string `yaml:"deadline"`
+}
+
+// Retry is the parsed retry strategy.
+type Retry struct {
+	Count       int
+	Interval    time.Duration
+	Backoff     string
+	MaxInterval time.Duration
+	Jitter      float64
+	Deadline    time.Duration
+}
+
+// Parse parses the retry strategy, the name is the path of the strategy in the config, used in the error messages.
+// The interval in number is in milliseconds for compatibility.
+func (s *VerifyRetryStrategy) Parse(name string) (*Retry, error) {
+	r := &Retry{Count: s.Count, Backoff: s.Backoff, Jitter: s.Jitter}
+	if r.Count < 0 {
+		r.Count = 0
+	}
+
+	var err error
+	if r.Interval, err = parseInterval(s.Interval, name+".interval", time.Millisecond); err != nil {
+		return nil, err
+	}
+	if r.Interval < 0 {
+		r.Interval = defaultRetryInterval
+	}
+	if r.MaxInterval, err = parseInterval(s.MaxInterval, name+".max-interval", time.Millisecond); err != nil {
+		return nil, err
+	}
+	if r.Deadline, err = parseInterval(s.Deadline, name+".deadline", time.Millisecond); err != nil {
+		return nil, err
+	}
+
+	switch r.Backoff {
+	case "":
+		r.Backoff = BackoffFixed
+	case BackoffFixed, BackoffExponential:
+	default:
+		return nil, fmt.Errorf("%s.backoff should be %s or %s, but was %s", name, BackoffFixed, BackoffExponential, r.Backoff)
+	}
+	if r.Jitter < 0 || r.Jitter > 1 {
+		return nil, fmt.Errorf("%s.jitter should be between 0 and 1, but was %v", name, r.Jitter)
+	}
+	return r, nil
+}
+
+// Override returns the retry strategy with the fields set in the strategy of a case overriding the ones of r,
+// the name is the path of the case strategy in the config.
+func (r *Retry) Override(s *VerifyRetryStrategy, name string) (*Retry, error) {
+	if s == nil {
+		return r, nil
+	}
+	parsed, err := s.Parse(name)
+	if err != nil {
+		return nil, err
+	}
+
+	merged := *r
+	if s.Count > 0 {
+		merged.Count = parsed.Count
+	}
+	if s.Interval != nil {
+		merged.Interval = parsed.Interval
+	}
+	if s.Backoff != "" {
+		merged.Backoff = parsed.Backoff
+	}
+	if s.MaxInterval != "" {
+		merged.MaxInterval = parsed.MaxInterval
+	}
+	if s.Jitter != 0 {
+		merged.Jitter = parsed.Jitter
+	}
+	if s.Deadline != "" {
+		merged.Deadline = parsed.Deadline
+	}
+	return &merged, nil
+}
+
+// Delay returns the interval before the n-th retry, n starts from 1.
+func (r *Retry) Delay(n int) time.Duration {
+	delay := float64(r.Interval)
+	if r.Backoff == BackoffExponential {
+		delay *= math.Pow(2, float64(n-1))
+	}
+	if r.MaxInterval > 0 && delay > float64(r.MaxInterval) {
+		delay = float64(r.MaxInterval)
+	}
+	if r.Jitter > 0 {
+		//nolint:gosec // the jitter doesn't need a secure random number
+		delay *= 1 + r.Jitter*(2*rand.Float64()-1)
+	}
+	if delay > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return time.Duration(delay)
+}
+
+// Next returns the interval before the n-th retry of the case started at start, n starts from 1,
+// and whether the retry should be made according to the count and the deadline.
+func (r *Retry) Next(n int, start time.Time) (time.Duration, bool) {
+	delay := r.Delay(n)
+	if r.Deadline <= 0 {
+		return delay, n <= r.Count
+	}
+	if time.Since(start)+delay >= r.Deadline {
+		return 0, false
+	}
+	return delay, r.Count <= 0 || n <= r.Count
+}
+
+// Progress returns the progress of the n-th retry, such as `3/10`, or `3` if the count is unlimited.
+func (r *Retry) Progress(n int) string {
+	if r.Deadline > 0 && r.Count <= 0 {
+		return strconv.Itoa(n)
+	}
+	return fmt.Sprintf("%d/%d", n, r.Count)
+}
+
+// CaseStrategy returns the retry strategy of the case overriding r with the strategy s of the case,
+// and the context which is done when the timeout of the case is reached, the name is the name of the case.
+func (r *Retry) CaseStrategy(name string, s *VerifyRetryStrategy, timeout string) (*Retry, context.Context, context.CancelFunc, error) {
+	retry, err := r.Override(s, fmt.Sprintf("retry of %v", name))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	d, err := parseCaseTimeout(timeout)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if d > 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), d)
+		return retry, ctx, cancel, nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return retry, ctx, cancel, nil
+}
+
+// CaseFailureMessage returns the message of the failed case, which tells whether the case timed out,
+// the action is what is done to the case, such as verify or assert.
+func CaseFailureMessage(ctx context.Context, action, name string, retried int) string {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Sprintf("failed to %s %v, timed out, retried %d time(s):", action, name, retried)
+	}
+	return fmt.Sprintf("failed to %s %v, retried %d time(s):", action, name, retried)
+}
+
+func parseCaseTimeout(timeout string) (time.Duration, error) {
+	if timeout == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse the timeout of case: %v", err)
+	}
+	return d, nil
+}
+
+// parseInterval parses a Duration field with number and string content for compatibility,
+// only use this when we previously allow configuring number like 120 and now string like 2m,
+// the number is in the unit.
+// TODO remove this in 2.0
+func parseInterval(value any, name string, unit time.Duration) (time.Duration, error) {
+	switch v := value.(type) {
+	case nil:
+		return 0, nil
+	case int:
+		logger.Log.Warnf("configuring %v with number %v is deprecated and will be removed in future version,"+
+			" please use Duration style instead, such as 10s, 1m.", name, v)
+		return time.Duration(v) * unit, nil
+	case string:
+		if v == "" {
+			return 0, nil
+		}
+		interval, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %v: %v", name, err)
+		}
+		return interval, nil
+	default:
+		return 0, fmt.Errorf("failed to parse %v: %v", name, value)
+	}
+}
@@ -0,0 +1,166 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   any
+		unit    time.Duration
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "Backward compatibility, should parse numeric value in milliseconds", value: 1000, unit: time.Millisecond, want: time.Second},
+		{name: "Backward compatibility, should parse numeric value in seconds", value: 120, unit: time.Second, want: 2 * time.Minute},
+		{name: "Should parse duration like 10s", value: "10s", unit: time.Millisecond, want: 10 * time.Second},
+		{name: "Should fail in other cases", value: "abcdef", unit: time.Millisecond, wantErr: true},
+		{name: "Should parse interval without setting value", unit: time.Millisecond, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInterval(tt.value, "verify.retry.interval", tt.unit)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseInterval() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("parseInterval() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifyRetryStrategy_Parse(t *testing.T) {
+	r, err := (&VerifyRetryStrategy{Count: 3, Interval: "-10s"}).Parse("verify.retry")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if r.Interval != time.Second || r.Backoff != BackoffFixed {
+		t.Errorf("Parse() = %+v, want the default interval 1s and fixed backoff", r)
+	}
+
+	for _, s := range []*VerifyRetryStrategy{
+		{Backoff: "linear"},
+		{Jitter: 1.5},
+		{MaxInterval: "ten seconds"},
+		{Deadline: "ten minutes"},
+	} {
+		if _, err := s.Parse("verify.retry"); err == nil {
+			t.Errorf("Parse(%+v) should fail", s)
+		}
+	}
+}
+
+func TestRetry_Delay(t *testing.T) {
+	fixed := &Retry{Interval: time.Second, Backoff: BackoffFixed}
+	exponential := &Retry{Interval: time.Second, Backoff: BackoffExponential, MaxInterval: 5 * time.Second}
+	for n, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 100: 5 * time.Second} {
+		if got := fixed.Delay(n); got != time.Second {
+			t.Errorf("fixed Delay(%d) = %v, want %v", n, got, time.Second)
+		}
+		if got := exponential.Delay(n); got != want {
+			t.Errorf("exponential Delay(%d) = %v, want %v", n, got, want)
+		}
+	}
+
+	jitter := &Retry{Interval: time.Second, Backoff: BackoffFixed, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		if got := jitter.Delay(1); got < 800*time.Millisecond || got > 1200*time.Millisecond {
+			t.Fatalf("jitter Delay(1) = %v, want between 800ms and 1.2s", got)
+		}
+	}
+}
+
+func TestRetry_Next(t *testing.T) {
+	counted := &Retry{Count: 2, Interval: time.Millisecond}
+	if _, ok := counted.Next(2, time.Now()); !ok {
+		t.Errorf("Next(2) should retry with count 2")
+	}
+	if _, ok := counted.Next(3, time.Now()); ok {
+		t.Errorf("Next(3) should not retry with count 2")
+	}
+
+	deadline := &Retry{Interval: time.Second, Deadline: time.Minute}
+	if _, ok := deadline.Next(100, time.Now()); !ok {
+		t.Errorf("Next(100) should retry before the deadline with unlimited count")
+	}
+	if _, ok := deadline.Next(1, time.Now().Add(-time.Minute)); ok {
+		t.Errorf("Next(1) should not retry after the deadline")
+	}
+	if _, ok := (&Retry{Count: 1, Interval: time.Second, Deadline: time.Minute}).Next(2, time.Now()); ok {
+		t.Errorf("Next(2) should not retry with count 1 before the deadline")
+	}
+}
+
+func TestRetry_Override(t *testing.T) {
+	suite := &Retry{Count: 10, Interval: time.Second, Backoff: BackoffFixed, Jitter: 0.1}
+	if r, err := suite.Override(nil, "retry"); err != nil || r != suite {
+		t.Errorf("Override(nil) = %+v, %v, want the suite strategy", r, err)
+	}
+
+	r, err := suite.Override(&VerifyRetryStrategy{Count: 30, Backoff: BackoffExponential, MaxInterval: "1m"}, "retry")
+	if err != nil {
+		t.Fatalf("Override() error = %v", err)
+	}
+	want := Retry{Count: 30, Interval: time.Second, Backoff: BackoffExponential, MaxInterval: time.Minute, Jitter: 0.1}
+	if *r != want {
+		t.Errorf("Override() = %+v, want %+v", *r, want)
+	}
+
+	if _, err := suite.Override(&VerifyRetryStrategy{Interval: "1 second"}, "retry"); err == nil {
+		t.Errorf("Override() should fail with invalid interval")
+	}
+}
+
+func TestRetry_CaseStrategy(t *testing.T) {
+	suite := &Retry{Count: 10, Interval: time.Second, Backoff: BackoffFixed}
+	r, ctx, cancel, err := suite.CaseStrategy("case", &VerifyRetryStrategy{Count: 3}, "10ms")
+	if err != nil {
+		t.Fatalf("CaseStrategy() error = %v", err)
+	}
+	defer cancel()
+	if r.Count != 3 {
+		t.Errorf("CaseStrategy() count = %d, want 3", r.Count)
+	}
+	<-ctx.Done()
+	if msg := CaseFailureMessage(ctx, "verify", "case", 2); msg != "failed to verify case, timed out, retried 2 time(s):" {
+		t.Errorf("CaseFailureMessage() = %q", msg)
+	}
+
+	_, ctx, cancel, err = suite.CaseStrategy("case", nil, "")
+	if err != nil {
+		t.Fatalf("CaseStrategy() error = %v", err)
+	}
+	if _, ok := ctx.Deadline(); ok {
+		t.Errorf("CaseStrategy() should not time out without the timeout")
+	}
+	cancel()
+	if msg := CaseFailureMessage(ctx, "assert", "case", 0); msg != "failed to assert case, retried 0 time(s):" {
+		t.Errorf("CaseFailureMessage() = %q", msg)
+	}
+
+	if _, _, _, err := suite.CaseStrategy("case", nil, "1 minute"); err == nil {
+		t.Errorf("CaseStrategy() should fail with invalid timeout")
+	}
+}
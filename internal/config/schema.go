@@ -0,0 +1,113 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"reflect"
+
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+)
+
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// schemaEnums are the allowed values of the fields, keyed by their paths in the config.
+var schemaEnums = map[string][]string{
+	"setup.env":             {constant.Kind, constant.Compose},
+	"setup.compose.driver":  {constant.ComposeDriverAuto, constant.ComposeDriverStandalone, constant.ComposeDriverPlugin},
+	"setup.steps.wait.type": {constant.WaitHTTP, constant.WaitTCP, constant.WaitCommand, constant.WaitLog},
+	"setup.compose.services.wait.type": {
+		constant.ComposeWaitHealthy, constant.ComposeWaitHTTP, constant.ComposeWaitLog, constant.ComposeWaitExec,
+	},
+	"cleanup.on":                 {constant.CleanUpAlways, constant.CleanUpOnSuccess, constant.CleanUpOnFailure, constant.CleanUpNever},
+	"trigger.action":             {constant.ActionHTTP, constant.ActionHeraHTTP, constant.ActionCMD, constant.ActionGRPC},
+	"merge":                      {MergeAppend, MergeReplace},
+	"verify.retry.backoff":       {BackoffFixed, BackoffExponential},
+	"assert.retry.backoff":       {BackoffFixed, BackoffExponential},
+	"verify.cases.retry.backoff": {BackoffFixed, BackoffExponential},
+	"assert.cases.retry.backoff": {BackoffFixed, BackoffExponential},
+}
+
+// Schema is the JSON Schema of a config field.
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Type                 any                `json:"type,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	OneOf                []*Schema          `json:"oneOf,omitempty"`
+}
+
+// E2EConfigSchema generates the JSON Schema of the e2e config file from the config structs.
+func E2EConfigSchema() *Schema {
+	s := generateSchema(reflect.TypeOf(E2EConfig{}), "")
+	s.Schema, s.Title = jsonSchemaDraft, "e2e.yaml"
+	return s
+}
+
+// ReusingCasesSchema generates the JSON Schema of the reusing verify cases file from the config structs.
+func ReusingCasesSchema() *Schema {
+	// the cases in the file share the enums of the cases in the verify config.
+	s := generateSchema(reflect.TypeOf(ReusingCases{}), "verify")
+	s.Schema, s.Title = jsonSchemaDraft, "reusing cases"
+	return s
+}
+
+// ReusingAssertCasesSchema generates the JSON Schema of the reusing assert cases file from the config structs.
+func ReusingAssertCasesSchema() *Schema {
+	s := generateSchema(reflect.TypeOf(ReusingAssertCases{}), "assert")
+	s.Schema, s.Title = jsonSchemaDraft, "reusing assert cases"
+	return s
+}
+
+func generateSchema(t reflect.Type, path string) *Schema {
+	switch t.Kind() {
+	case reflect.Ptr:
+		return generateSchema(t.Elem(), path)
+	case reflect.Struct:
+		s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+		for name, field := range yamlFields(t) {
+			s.Properties[name] = generateSchema(field, joinPath(path, name))
+		}
+		return s
+	case reflect.Slice:
+		items := generateSchema(t.Elem(), path)
+		// Triggers could be a single trigger or a list of triggers.
+		if t == reflect.TypeOf(Triggers{}) {
+			return &Schema{OneOf: []*Schema{items, {Type: "array", Items: items}}}
+		}
+		return &Schema{Type: "array", Items: items}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: generateSchema(t.Elem(), path)}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer"}
+	case reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.String:
+		return &Schema{Type: "string", Enum: schemaEnums[path]}
+	case reflect.Interface:
+		// the fields with number and string content for compatibility, see parseInterval.
+		return &Schema{Type: []string{"integer", "string"}}
+	default:
+		return &Schema{}
+	}
+}
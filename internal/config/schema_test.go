@@ -0,0 +1,69 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestE2EConfigSchema(t *testing.T) {
+	s := E2EConfigSchema()
+
+	if got, want := s.Properties["setup"].Properties["env"].Enum, []string{"kind", "compose"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("setup.env enum = %v, want %v", got, want)
+	}
+	if got, want := s.Properties["cleanup"].Properties["on"].Enum, []string{"always", "success", "failure", "never"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cleanup.on enum = %v, want %v", got, want)
+	}
+	if _, ok := s.Properties["setup"].Properties["init-system-environment"]; !ok {
+		t.Errorf("the properties should be named by the yaml tags")
+	}
+	if _, ok := s.Properties["setup"].Properties["timeout"]; !ok {
+		t.Errorf("setup.timeout should be in the schema")
+	}
+
+	for _, name := range []string{"verify", "assert"} {
+		backoff := s.Properties[name].Properties["cases"].Items.Properties["retry"].Properties["backoff"].Enum
+		if want := []string{BackoffFixed, BackoffExponential}; !reflect.DeepEqual(backoff, want) {
+			t.Errorf("%s.cases.retry.backoff enum = %v, want %v", name, backoff, want)
+		}
+	}
+
+	trigger := s.Properties["trigger"]
+	if len(trigger.OneOf) != 2 || trigger.OneOf[1].Items != trigger.OneOf[0] {
+		t.Errorf("trigger should be a single trigger or a list of triggers")
+	}
+}
+
+func TestReusingCasesSchema(t *testing.T) {
+	backoff := []string{BackoffFixed, BackoffExponential}
+	for name, s := range map[string]*Schema{"verify": ReusingCasesSchema(), "assert": ReusingAssertCasesSchema()} {
+		cases := s.Properties["cases"]
+		if cases.Type != "array" || cases.Items.Properties["includes"].Items.Type != "string" {
+			t.Errorf("unexpected reusing %s cases schema: %+v", name, cases)
+		}
+		if got := cases.Items.Properties["retry"].Properties["backoff"].Enum; !reflect.DeepEqual(got, backoff) {
+			t.Errorf("reusing %s cases retry.backoff enum = %v, want %v", name, got, backoff)
+		}
+	}
+	if _, ok := ReusingAssertCasesSchema().Properties["cases"].Items.Properties["query"]; !ok {
+		t.Errorf("the reusing assert cases schema should be generated from the assert cases")
+	}
+}
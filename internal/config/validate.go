@@ -0,0 +1,562 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/apache/skywalking-infra-e2e/internal/constant"
+	"github.com/apache/skywalking-infra-e2e/internal/util"
+)
+
+var (
+	// triggerCommonFields are the fields allowed by all the trigger actions.
+	triggerCommonFields = []string{"name", "action", "interval", "times"}
+	// triggerFields are the fields allowed by each trigger action, besides the common ones.
+	triggerFields = map[string][]string{
+		constant.ActionHTTP:     {"url", "method", "body", "headers", "template", "load", "expect"},
+		constant.ActionHeraHTTP: {"url", "method", "body", "headers", "template", "expect"},
+		constant.ActionCMD:      {"command"},
+		constant.ActionGRPC:     {"url", "method", "body", "headers", "template", "descriptor-set"},
+	}
+	// triggerRequiredFields are the fields required by each trigger action.
+	triggerRequiredFields = map[string][]string{
+		constant.ActionHTTP:     {"url"},
+		constant.ActionHeraHTTP: {"url"},
+		constant.ActionCMD:      {"command"},
+		constant.ActionGRPC:     {"url", "method"},
+	}
+)
+
+// Problem is a problem found in the e2e config file, or the files it includes.
+type Problem struct {
+	File   string
+	Line   int
+	Column int
+	Msg    string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", p.File, p.Line, p.Column, p.Msg)
+}
+
+// validator strictly checks the e2e config file and collects all the problems.
+type validator struct {
+	problems []*Problem
+	// visited are the config files validated, to avoid validating the extended files circularly.
+	visited map[string]bool
+}
+
+// Validate strictly checks the e2e config file, including the unknown fields, the types of the fields,
+// and the rules across the fields, then returns all the problems sorted by their positions.
+func Validate(file string) []*Problem {
+	// the paths in the config could reference the variables, export them like loading the config, and restore afterwards.
+	defer util.RestoreEnv(os.Environ())
+	// the fields of the invalid config are still decoded as much as possible, the problems are reported by the checks.
+	cfg, _ := loadE2EConfig(file)
+	if cfg.Matrix.exportDefaults() == nil {
+		_ = exportVars(cfg.Vars)
+	}
+
+	v := &validator{visited: make(map[string]bool)}
+	v.checkConfigFile(file)
+
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems
+}
+
+func (v *validator) report(file string, node *yaml.Node, format string, args ...any) {
+	p := &Problem{File: file, Msg: fmt.Sprintf(format, args...)}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	v.problems = append(v.problems, p)
+}
+
+// parse parses the file into yaml node, the problem is reported if the file can not be parsed.
+func (v *validator) parse(file string) *yaml.Node {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		v.report(file, nil, "failed to read the file: %v", err)
+		return nil
+	}
+
+	doc := &yaml.Node{}
+	if err := yaml.Unmarshal(data, doc); err != nil {
+		v.report(file, nil, "failed to parse the file: %v", err)
+		return nil
+	}
+	if len(doc.Content) == 0 {
+		v.report(file, doc, "the file is empty")
+		return nil
+	}
+	return doc.Content[0]
+}
+
+// checkType checks the node matches the type, the unknown fields of struct are reported.
+func (v *validator) checkType(file string, node *yaml.Node, t reflect.Type, path string) {
+	node = resolveAlias(node)
+	if node.Tag == "!!null" || t.Kind() == reflect.Interface {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		v.checkType(file, node, t.Elem(), path)
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			v.report(file, node, "%s should be a mapping", describe(path))
+			return
+		}
+		fields := yamlFields(t)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				v.report(file, key, "unknown field %q in %s", key.Value, describe(path))
+				continue
+			}
+			v.checkType(file, value, field, joinPath(path, key.Value))
+		}
+	case reflect.Slice:
+		// Triggers could be a single trigger or a list of triggers.
+		if node.Kind == yaml.MappingNode && t == reflect.TypeOf(Triggers{}) {
+			v.checkType(file, node, t.Elem(), path)
+			return
+		}
+		if node.Kind != yaml.SequenceNode {
+			v.report(file, node, "%s should be a list", describe(path))
+			return
+		}
+		for idx, item := range node.Content {
+			v.checkType(file, item, t.Elem(), fmt.Sprintf("%s[%d]", path, idx))
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			v.report(file, node, "%s should be a mapping", describe(path))
+			return
+		}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			v.checkType(file, node.Content[i+1], t.Elem(), joinPath(path, node.Content[i].Value))
+		}
+	case reflect.Int, reflect.Int64:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			v.report(file, node, "%s should be an integer", describe(path))
+		}
+	case reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!float" && node.Tag != "!!int") {
+			v.report(file, node, "%s should be a number", describe(path))
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			v.report(file, node, "%s should be a boolean", describe(path))
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			v.report(file, node, "%s should be a string", describe(path))
+		}
+	}
+}
+
+// checkConfigFile checks the e2e config file, and the base config file it extends.
+func (v *validator) checkConfigFile(file string) {
+	v.visited[absPath(file)] = true
+	if root := v.parse(file); root != nil {
+		v.checkType(file, root, reflect.TypeOf(E2EConfig{}), "")
+		v.checkE2EConfig(file, root)
+	}
+}
+
+// checkE2EConfig checks the rules across the fields of the e2e config.
+func (v *validator) checkE2EConfig(file string, root *yaml.Node) {
+	extends := mappingValue(root, "extends")
+	if extends != nil && extends.Value != "" {
+		path := util.ResolveAbsWithBase(extends.Value, absPath(file))
+		switch {
+		case !util.PathExist(path):
+			v.report(file, extends, "extends file %s does not exist", path)
+		case v.visited[path]:
+			v.report(file, extends, "circular extends of file %s", path)
+		default:
+			v.checkConfigFile(path)
+		}
+	}
+
+	if setup := mappingValue(root, "setup"); setup != nil {
+		v.checkSetup(file, setup, extends == nil)
+	} else if extends == nil {
+		v.report(file, root, "setup is required")
+	}
+
+	if on := mappingValue(mappingValue(root, "cleanup"), "on"); on != nil {
+		switch on.Value {
+		case constant.CleanUpAlways, constant.CleanUpOnSuccess, constant.CleanUpOnFailure, constant.CleanUpNever:
+		default:
+			v.report(file, on, "cleanup.on should be one of %s, %s, %s, %s, but was %q",
+				constant.CleanUpAlways, constant.CleanUpOnSuccess, constant.CleanUpOnFailure, constant.CleanUpNever, on.Value)
+		}
+	}
+
+	if trigger := resolveAlias(mappingValue(root, "trigger")); trigger != nil {
+		if trigger.Kind == yaml.MappingNode {
+			v.checkTrigger(file, trigger)
+		} else if trigger.Kind == yaml.SequenceNode {
+			for _, t := range trigger.Content {
+				v.checkTrigger(file, resolveAlias(t))
+			}
+		}
+	}
+
+	for path, mode := range mappingPairs(mappingValue(root, "merge")) {
+		if mode.Value != MergeAppend && mode.Value != MergeReplace {
+			v.report(file, mode, "merge mode of %s should be %s or %s, but was %q", path, MergeAppend, MergeReplace, mode.Value)
+		}
+	}
+
+	for _, section := range []string{"verify", "assert"} {
+		v.checkRetry(file, mappingValue(mappingValue(root, section), "retry"), section+".retry")
+	}
+
+	for name, values := range mappingPairs(mappingValue(root, "matrix")) {
+		if values.Kind == yaml.SequenceNode && len(values.Content) == 0 {
+			v.report(file, values, "matrix.%s should have at least one value", name)
+		}
+	}
+
+	for idx, c := range sequenceItems(mappingValue(mappingValue(root, "assert"), "cases")) {
+		v.checkCase(file, c, reflect.TypeOf(ReusingAssertCases{}), fmt.Sprintf("assert.cases[%d]", idx))
+	}
+	for idx, c := range sequenceItems(mappingValue(mappingValue(root, "verify"), "cases")) {
+		v.checkCase(file, c, reflect.TypeOf(ReusingCases{}), fmt.Sprintf("verify.cases[%d]", idx))
+	}
+}
+
+func (v *validator) checkSetup(file string, setup *yaml.Node, required bool) {
+	env := mappingValue(setup, "env")
+	switch {
+	case env == nil:
+		if required {
+			v.report(file, setup, "setup.env is required")
+		}
+	case env.Value != constant.Kind && env.Value != constant.Compose:
+		v.report(file, env, "setup.env should be %s or %s, but was %q", constant.Kind, constant.Compose, env.Value)
+	}
+
+	if driver := mappingValue(mappingValue(setup, "compose"), "driver"); driver != nil {
+		switch driver.Value {
+		case constant.ComposeDriverAuto, constant.ComposeDriverStandalone, constant.ComposeDriverPlugin:
+		default:
+			v.report(file, driver, "setup.compose.driver should be one of %s, %s, %s, but was %q",
+				constant.ComposeDriverAuto, constant.ComposeDriverStandalone, constant.ComposeDriverPlugin, driver.Value)
+		}
+	}
+
+	for idx, service := range sequenceItems(mappingValue(mappingValue(setup, "compose"), "services")) {
+		v.checkComposeService(file, service, fmt.Sprintf("setup.compose.services[%d]", idx))
+	}
+
+	if kubeconfig := mappingValue(setup, "kubeconfig"); kubeconfig != nil && mappingValue(setup, "file") != nil {
+		v.report(file, kubeconfig, "only one of setup.file and setup.kubeconfig could be specified")
+	}
+
+	for idx, step := range sequenceItems(mappingValue(setup, "steps")) {
+		hasPath, hasCommand := scalarValue(step, "path") != "", scalarValue(step, "command") != ""
+		if hasPath == hasCommand {
+			v.report(file, step, "setup.steps[%d] should specify exactly one of path and command", idx)
+		}
+		for waitIdx, wait := range sequenceItems(mappingValue(step, "wait")) {
+			v.checkStepWait(file, wait, fmt.Sprintf("setup.steps[%d].wait[%d]", idx, waitIdx))
+		}
+	}
+}
+
+func (v *validator) checkStepWait(file string, wait *yaml.Node, path string) {
+	t := mappingValue(wait, "type")
+	if t == nil {
+		return
+	}
+	var required []string
+	switch t.Value {
+	case constant.WaitHTTP:
+		required = []string{"url"}
+	case constant.WaitTCP:
+		required = []string{"address"}
+	case constant.WaitCommand:
+		required = []string{"command"}
+	case constant.WaitLog:
+		required = []string{"resource", "regex"}
+	default:
+		v.report(file, t, "%s.type should be one of %s, %s, %s, %s, but was %q", path,
+			constant.WaitHTTP, constant.WaitTCP, constant.WaitCommand, constant.WaitLog, t.Value)
+		return
+	}
+	for _, field := range required {
+		if scalarValue(wait, field) == "" {
+			v.report(file, wait, "%s.%s is required by the %s wait", path, field, t.Value)
+		}
+	}
+	v.checkDuration(file, mappingValue(wait, "interval"), joinPath(path, "interval"))
+	v.checkDuration(file, mappingValue(wait, "timeout"), joinPath(path, "timeout"))
+}
+
+func (v *validator) checkComposeService(file string, service *yaml.Node, path string) {
+	if scalarValue(service, "name") == "" {
+		v.report(file, service, "%s.name is required", path)
+	}
+	v.checkDuration(file, mappingValue(service, "timeout"), joinPath(path, "timeout"))
+
+	for idx, wait := range sequenceItems(mappingValue(service, "wait")) {
+		waitPath := fmt.Sprintf("%s.wait[%d]", path, idx)
+		var required string
+		switch t := mappingValue(wait, "type"); {
+		case t == nil:
+			v.report(file, wait, "%s.type is required", waitPath)
+			continue
+		case t.Value == constant.ComposeWaitHealthy:
+		case t.Value == constant.ComposeWaitHTTP:
+			required = "port"
+		case t.Value == constant.ComposeWaitLog:
+			required = "regex"
+		case t.Value == constant.ComposeWaitExec:
+			required = "command"
+		default:
+			v.report(file, t, "%s.type should be one of %s, %s, %s, %s, but was %q", waitPath,
+				constant.ComposeWaitHealthy, constant.ComposeWaitHTTP, constant.ComposeWaitLog, constant.ComposeWaitExec, t.Value)
+			continue
+		}
+		if required != "" && scalarValue(wait, required) == "" {
+			v.report(file, wait, "%s.%s is required by the %s wait", waitPath, required, scalarValue(wait, "type"))
+		}
+	}
+}
+
+func (v *validator) checkTrigger(file string, trigger *yaml.Node) {
+	if trigger.Kind != yaml.MappingNode {
+		return
+	}
+	action := mappingValue(trigger, "action")
+	if action == nil || action.Value == "" {
+		return
+	}
+	fields, ok := triggerFields[action.Value]
+	if !ok {
+		v.report(file, action, "unsupported trigger action %q", action.Value)
+		return
+	}
+
+	allowed := append(append([]string{}, triggerCommonFields...), fields...)
+	for i := 0; i+1 < len(trigger.Content); i += 2 {
+		if key := trigger.Content[i]; !contains(allowed, key.Value) {
+			v.report(file, key, "field %q is not supported by the %s trigger", key.Value, action.Value)
+		}
+	}
+	for _, field := range triggerRequiredFields[action.Value] {
+		if scalarValue(trigger, field) == "" {
+			v.report(file, trigger, "field %q is required by the %s trigger", field, action.Value)
+		}
+	}
+}
+
+// checkRetry checks the retry strategy of the suite or the case.
+func (v *validator) checkRetry(file string, retry *yaml.Node, path string) {
+	if backoff := mappingValue(retry, "backoff"); backoff != nil && backoff.Value != BackoffFixed && backoff.Value != BackoffExponential {
+		v.report(file, backoff, "%s.backoff should be %s or %s, but was %q", path, BackoffFixed, BackoffExponential, backoff.Value)
+	}
+	if jitter := mappingValue(retry, "jitter"); jitter != nil {
+		if value, err := strconv.ParseFloat(jitter.Value, 64); err == nil && (value < 0 || value > 1) {
+			v.report(file, jitter, "%s.jitter should be between 0 and 1, but was %v", path, value)
+		}
+	}
+	for _, key := range []string{"max-interval", "deadline"} {
+		v.checkDuration(file, mappingValue(retry, key), joinPath(path, key))
+	}
+}
+
+// checkDuration checks the value of the node is a valid duration.
+func (v *validator) checkDuration(file string, node *yaml.Node, path string) {
+	if node == nil || node.Tag != "!!str" {
+		return
+	}
+	if _, err := time.ParseDuration(node.Value); err != nil {
+		v.report(file, node, "%s should be a duration, such as 10s, 1m, but was %q", path, node.Value)
+	}
+}
+
+// checkCase checks the files referenced by the case exist, the included files are validated recursively.
+func (v *validator) checkCase(file string, c *yaml.Node, reusingType reflect.Type, path string) {
+	v.checkRetry(file, mappingValue(c, "retry"), joinPath(path, "retry"))
+	v.checkDuration(file, mappingValue(c, "timeout"), joinPath(path, "timeout"))
+
+	for _, field := range []string{"expected", "actual"} {
+		if node := mappingValue(c, field); node != nil && node.Value != "" {
+			if path, ok := expandPath(node.Value, file); ok && !util.PathExist(path) {
+				v.report(file, node, "%s file %s does not exist", field, path)
+			}
+		}
+	}
+
+	for _, include := range sequenceItems(mappingValue(c, "includes")) {
+		path, ok := expandPath(include.Value, file)
+		if !ok {
+			continue
+		}
+		if !util.PathExist(path) {
+			v.report(file, include, "include file %s does not exist", path)
+			continue
+		}
+		root := v.parse(path)
+		if root == nil {
+			continue
+		}
+		v.checkType(path, root, reusingType, "")
+		for idx, included := range sequenceItems(mappingValue(root, "cases")) {
+			v.checkCase(path, included, reusingType, fmt.Sprintf("cases[%d]", idx))
+		}
+	}
+}
+
+// expandPath expands the environment variables in the path referenced by the file and resolves it,
+// it's not ok if the path can not be expanded, such as it requires a variable which is only set at run time.
+func expandPath(value, file string) (string, bool) {
+	expanded, err := util.ExpandEnv(value)
+	if err != nil || expanded == "" {
+		return "", false
+	}
+	return util.ResolveAbsWithBase(expanded, absPath(file)), true
+}
+
+func yamlFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if name := yamlFieldName(f); f.PkgPath == "" && name != "-" {
+			fields[name] = f.Type
+		}
+	}
+	return fields
+}
+
+// yamlFieldName returns the yaml name of the struct field.
+func yamlFieldName(f reflect.StructField) string {
+	if name := strings.Split(f.Tag.Get("yaml"), ",")[0]; name != "" {
+		return name
+	}
+	return strings.ToLower(f.Name)
+}
+
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node != nil && node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
+
+// mappingValue returns the value node of the key in the mapping node, or nil if not found.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return resolveAlias(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// mappingPairs returns the value nodes of the mapping node, keyed by their keys.
+func mappingPairs(node *yaml.Node) map[string]*yaml.Node {
+	node = resolveAlias(node)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	pairs := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		pairs[node.Content[i].Value] = resolveAlias(node.Content[i+1])
+	}
+	return pairs
+}
+
+func scalarValue(node *yaml.Node, key string) string {
+	if value := mappingValue(node, key); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value
+	}
+	return ""
+}
+
+func sequenceItems(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.SequenceNode {
+		return nil
+	}
+	items := make([]*yaml.Node, 0, len(node.Content))
+	for _, item := range node.Content {
+		items = append(items, resolveAlias(item))
+	}
+	return items
+}
+
+func describe(path string) string {
+	if path == "" {
+		return "the root"
+	}
+	return path
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func absPath(file string) string {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return file
+	}
+	return abs
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"expected.yaml": "",
+		"cases.yaml": `cases:
+  - query: echo
+    expected: missing.yaml
+    retries: 3
+    retry:
+      backoff: linear
+`,
+		"e2e.yaml": `setup:
+  env: docker
+  file: docker-compose.yml
+  kubeconfig: kubeconfig.yaml
+  steps:
+    - name: both
+      path: a.yaml
+      command: echo
+    - name: none
+      wait:
+        - type: log
+          regex: started
+  compose:
+    driver: v2
+    services:
+      - name: oap
+        timeout: soon
+        wait:
+          - type: http
+          - type: tcp
+cleanup:
+  on: sometimes
+trigger:
+  - action: cmd
+    command: echo
+    url: http://localhost
+  - action: grpc
+    url: localhost:9090
+verify:
+  retry:
+    count: ten
+  fail_fast: true
+  cases:
+    - query: echo
+      expected: expected.yaml
+    - includes:
+        - cases.yaml
+        - not-exist.yaml
+    - query: echo
+      expected: ${E2E_VALIDATE_EXPECTED}.yaml
+      actual: ${E2E_VALIDATE_UNSET:?set at run time}
+      retry:
+        deadline: soon
+    - query: echo
+      expected: ${E2E_VALIDATE_VERSION}.yaml
+vars:
+  E2E_VALIDATE_EXPECTED: expected
+matrix:
+  E2E_VALIDATE_VERSION: [v1, v2]
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	defer func() {
+		if _, ok := os.LookupEnv("E2E_VALIDATE_EXPECTED"); ok {
+			t.Errorf("Validate() doesn't restore the exported variables")
+		}
+	}()
+	for _, p := range Validate(filepath.Join(dir, "e2e.yaml")) {
+		got = append(got, fmt.Sprintf("%s:%d:%d: %s", filepath.Base(p.File), p.Line, p.Column, p.Msg))
+	}
+	want := []string{
+		`cases.yaml:3:15: expected file ` + filepath.Join(dir, "missing.yaml") + ` does not exist`,
+		`cases.yaml:4:5: unknown field "retries" in cases[0]`,
+		`cases.yaml:6:16: cases[0].retry.backoff should be fixed or exponential, but was "linear"`,
+		`e2e.yaml:2:8: setup.env should be kind or compose, but was "docker"`,
+		`e2e.yaml:4:15: only one of setup.file and setup.kubeconfig could be specified`,
+		`e2e.yaml:6:7: setup.steps[0] should specify exactly one of path and command`,
+		`e2e.yaml:9:7: setup.steps[1] should specify exactly one of path and command`,
+		`e2e.yaml:11:11: setup.steps[1].wait[0].resource is required by the log wait`,
+		`e2e.yaml:14:13: setup.compose.driver should be one of auto, standalone, plugin, but was "v2"`,
+		`e2e.yaml:17:18: setup.compose.services[0].timeout should be a duration, such as 10s, 1m, but was "soon"`,
+		`e2e.yaml:19:13: setup.compose.services[0].wait[0].port is required by the http wait`,
+		`e2e.yaml:20:19: setup.compose.services[0].wait[1].type should be one of healthy, http, log, exec, but was "tcp"`,
+		`e2e.yaml:22:7: cleanup.on should be one of always, success, failure, never, but was "sometimes"`,
+		`e2e.yaml:26:5: field "url" is not supported by the cmd trigger`,
+		`e2e.yaml:27:5: field "method" is required by the grpc trigger`,
+		`e2e.yaml:31:12: verify.retry.count should be an integer`,
+		`e2e.yaml:32:3: unknown field "fail_fast" in verify`,
+		`e2e.yaml:38:11: include file ` + filepath.Join(dir, "not-exist.yaml") + ` does not exist`,
+		`e2e.yaml:43:19: verify.cases[2].retry.deadline should be a duration, such as 10s, 1m, but was "soon"`,
+		`e2e.yaml:45:17: expected file ` + filepath.Join(dir, "v1.yaml") + ` does not exist`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Validate() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
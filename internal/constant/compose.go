@@ -21,4 +21,20 @@ package constant
 const (
 	Compose        = "compose"
 	ComposeCommand = "docker-compose"
+
+	// ComposeDriverAuto uses the standalone docker-compose if it's found in the PATH, otherwise the docker compose plugin.
+	ComposeDriverAuto = "auto"
+	// ComposeDriverStandalone runs the standalone docker-compose command.
+	ComposeDriverStandalone = "standalone"
+	// ComposeDriverPlugin runs the docker compose plugin, which is shipped with the newer docker.
+	ComposeDriverPlugin = "plugin"
+
+	// ComposeWaitHealthy waits for the docker healthcheck status of the container to be healthy.
+	ComposeWaitHealthy = "healthy"
+	// ComposeWaitHTTP waits for an HTTP endpoint of the container to respond with the expected status.
+	ComposeWaitHTTP = "http"
+	// ComposeWaitLog waits for a line of the container log to match the regular expression.
+	ComposeWaitLog = "log"
+	// ComposeWaitExec waits for a command executed in the container to exit with 0.
+	ComposeWaitExec = "exec"
 )
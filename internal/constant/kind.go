@@ -32,6 +32,7 @@ const (
 	K8sClusterConfigFileName = "e2e-k8s.config"
 	DefaultWaitTimeout       = 600 * time.Second
 	SingleDefaultWaitTimeout = 30 * 60 * time.Second
+	DefaultWaitInterval      = time.Second
 	StepTypeManifest         = "manifest"
 	StepTypeCommand          = "command"
 )
@@ -22,4 +22,5 @@ const (
 	ActionHTTP     = "http"
 	ActionCMD      = "cmd"
 	ActionHeraHTTP = "hera-http"
+	ActionGRPC     = "grpc"
 )
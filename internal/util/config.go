@@ -30,6 +30,11 @@ var (
 	WorkDir   string
 	LogDir    string
 	BatchMode bool
+	// Overrides are the config values overridden in command line, in the format of `path.to.key=value`.
+	Overrides []string
+	// Variant identifies the current matrix combination, it's added to the names of the resources
+	// such as the compose project and the kind cluster, so the combinations don't collide.
+	Variant string
 )
 
 // ResolveAbs resolves the relative path (relative to CfgFile) to an absolute file path.
@@ -19,11 +19,25 @@
 package util
 
 import (
+	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 )
 
+// RestoreEnv restores the environment variables to the snapshot taken by os.Environ,
+// the variables set after the snapshot are unset, and the changed ones are reset.
+func RestoreEnv(snapshot []string) {
+	os.Clearenv()
+	for _, kv := range snapshot {
+		// the variables of Windows drives could start with "=", such as "=C:=C:\".
+		if key, value, ok := strings.Cut(kv, "="); ok && key != "" {
+			_ = os.Setenv(key, value)
+		}
+	}
+}
+
 func AddShutDownHook(stopFunc func()) {
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -32,3 +46,33 @@ func AddShutDownHook(stopFunc func()) {
 		stopFunc()
 	}()
 }
+
+// ExpandEnv replaces ${var} or $var in the string according to the values of the current environment variables,
+// besides, ${var:-default} is replaced with the default value if the variable is unset or empty,
+// and ${var:?message} fails with the message if the variable is unset or empty.
+func ExpandEnv(s string) (string, error) {
+	var err error
+	expanded := os.Expand(s, func(name string) string {
+		if name, message, ok := strings.Cut(name, ":?"); ok {
+			value := os.Getenv(name)
+			if value == "" && err == nil {
+				if message == "" {
+					message = "parameter null or not set"
+				}
+				err = fmt.Errorf("%s: %s", name, message)
+			}
+			return value
+		}
+		if name, defaultValue, ok := strings.Cut(name, ":-"); ok {
+			if value := os.Getenv(name); value != "" {
+				return value
+			}
+			return defaultValue
+		}
+		return os.Getenv(name)
+	})
+	if err != nil {
+		return "", err
+	}
+	return expanded, nil
+}
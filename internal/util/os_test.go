@@ -0,0 +1,73 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+//
+
+package util
+
+import (
+	"os"
+	"testing"
+)
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("E2E_HOST", "127.0.0.1")
+	t.Setenv("E2E_EMPTY", "")
+
+	tests := []struct {
+		name    string
+		s       string
+		want    string
+		wantErr bool
+	}{
+		{name: "plain", s: "http://$E2E_HOST:${E2E_PORT}/", want: "http://127.0.0.1:/"},
+		{name: "default of set variable", s: "${E2E_HOST:-localhost}", want: "127.0.0.1"},
+		{name: "default of unset variable", s: "${E2E_PORT:-8080}", want: "8080"},
+		{name: "default of empty variable", s: "${E2E_EMPTY:-default}", want: "default"},
+		{name: "required variable", s: "${E2E_HOST:?host is required}", want: "127.0.0.1"},
+		{name: "required unset variable", s: "${E2E_PORT:?port is required}", wantErr: true},
+		{name: "required empty variable", s: "${E2E_EMPTY:?}", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandEnv(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpandEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ExpandEnv() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRestoreEnv(t *testing.T) {
+	t.Setenv("E2E_HOST", "127.0.0.1")
+	t.Setenv("E2E_PORT", "")
+	os.Unsetenv("E2E_PORT")
+	snapshot := os.Environ()
+
+	os.Setenv("E2E_HOST", "localhost")
+	os.Setenv("E2E_PORT", "8080")
+	RestoreEnv(snapshot)
+
+	if got := os.Getenv("E2E_HOST"); got != "127.0.0.1" {
+		t.Errorf("E2E_HOST = %v, want 127.0.0.1", got)
+	}
+	if _, ok := os.LookupEnv("E2E_PORT"); ok {
+		t.Errorf("E2E_PORT should be unset")
+	}
+}
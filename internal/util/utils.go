@@ -20,13 +20,16 @@ package util
 
 import (
 	"bytes"
+	"context"
 	_ "embed"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/apache/skywalking-infra-e2e/internal/logger"
 )
@@ -54,6 +57,11 @@ func ReadFileContent(filename string) (string, error) {
 
 // ExecuteCommand executes the given command and returns the result.
 func ExecuteCommand(cmd string) (stdout, stderr string, err error) {
+	return ExecuteCommandContext(context.Background(), cmd)
+}
+
+// ExecuteCommandContext executes the given command and returns the result, the command is killed when the context is done.
+func ExecuteCommandContext(ctx context.Context, cmd string) (stdout, stderr string, err error) {
 	hookScript, err := hookScript()
 	if err != nil {
 		return "", "", err
@@ -67,14 +75,40 @@ func ExecuteCommand(cmd string) (stdout, stderr string, err error) {
 	command := exec.Command("bash", "-ec", cmd)
 	sout, serr := bytes.Buffer{}, bytes.Buffer{}
 	command.Stdout, command.Stderr = &sout, &serr
+	if ctx.Done() != nil {
+		// the command could be canceled, so its subprocesses should be killed together.
+		setProcessGroup(command)
+	}
 
 	if err := command.Start(); err != nil {
 		return sout.String(), serr.String(), err
 	}
-	if err := command.Wait(); err != nil {
+	done := make(chan error, 1)
+	go func() {
+		done <- command.Wait()
+	}()
+	select {
+	case err := <-done:
 		return sout.String(), serr.String(), err
+	case <-ctx.Done():
+		// the output is dropped, since the subprocesses of the command may still be writing it.
+		if err := killProcessGroup(command); err != nil {
+			logger.Log.Warnf("failed to kill the command: %v", err)
+		}
+		return "", "", fmt.Errorf("the command is killed: %v", ctx.Err())
+	}
+}
+
+// SleepContext sleeps for the duration, and returns false if the context is done before that.
+func SleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
 	}
-	return sout.String(), serr.String(), nil
 }
 
 //go:embed hook.sh
@@ -0,0 +1,149 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Cases     []*junitTestCase `xml:"testcase"`
+
+	duration time.Duration
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitFailure    `xml:"failure,omitempty"`
+	Skipped    *struct{}        `xml:"skipped,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// junitReporter writes the case results in JUnit XML format.
+type junitReporter struct {
+	lock   sync.Mutex
+	path   string
+	suites []*junitTestSuite
+}
+
+func NewJUnitReporter(path string) Reporter {
+	return &junitReporter{path: path}
+}
+
+func (r *junitReporter) Report(suite string, results []*CaseResult) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.suites = append(r.suites, newJUnitTestSuite(suite, results))
+
+	report := &junitTestSuites{Suites: r.suites}
+	var total time.Duration
+	for _, s := range r.suites {
+		report.Tests += s.Tests
+		report.Failures += s.Failures
+		report.Skipped += s.Skipped
+		total += s.duration
+	}
+	report.Time = formatSeconds(total)
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal junit report: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create junit report directory: %v", err)
+	}
+	if err := os.WriteFile(r.path, append([]byte(xml.Header), data...), 0o600); err != nil {
+		return fmt.Errorf("failed to write junit report %s: %v", r.path, err)
+	}
+	return nil
+}
+
+func newJUnitTestSuite(suite string, results []*CaseResult) *junitTestSuite {
+	s := &junitTestSuite{
+		Name:      suite,
+		Tests:     len(results),
+		Timestamp: time.Now().Format(time.RFC3339),
+		Cases:     make([]*junitTestCase, 0, len(results)),
+	}
+
+	for _, cr := range results {
+		s.duration += cr.Duration
+		c := &junitTestCase{
+			Name:      cr.Name,
+			ClassName: suite,
+			Time:      formatSeconds(cr.Duration),
+			Properties: &junitProperties{Properties: []junitProperty{
+				{Name: "retries", Value: strconv.Itoa(cr.Retries)},
+			}},
+		}
+		switch {
+		case cr.Skip:
+			s.Skipped++
+			c.Skipped = &struct{}{}
+		case cr.Err != nil:
+			s.Failures++
+			c.Failure = &junitFailure{Message: cr.Msg, Contents: strings.Join(cr.FailureDiffs(MaxFailureDiffs), "\n\n")}
+		}
+		s.Cases = append(s.Cases, c)
+	}
+	s.Time = formatSeconds(s.duration)
+	return s
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 3, 64)
+}
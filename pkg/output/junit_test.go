@@ -0,0 +1,65 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestJUnitReporter_Report(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "junit.xml")
+	reporter := NewJUnitReporter(path)
+
+	if err := reporter.Report("verify", []*CaseResult{
+		{Name: "case-1", Duration: time.Second},
+		{Name: "case-2", Msg: "failed to verify case-2", Err: fmt.Errorf("diff"), Retries: 3, Duration: 2 * time.Second},
+	}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if err := reporter.Report("assert", []*CaseResult{{Name: "case-3", Skip: true}}); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read the report: %v", err)
+	}
+	report := &junitTestSuites{}
+	if err := xml.Unmarshal(data, report); err != nil {
+		t.Fatalf("failed to parse the report: %v", err)
+	}
+
+	if report.Tests != 3 || report.Failures != 1 || report.Skipped != 1 || report.Time != "3.000" {
+		t.Errorf("unexpected report summary: %+v", report)
+	}
+	if len(report.Suites) != 2 || report.Suites[0].Name != "verify" || report.Suites[1].Name != "assert" {
+		t.Fatalf("unexpected suites: %+v", report.Suites)
+	}
+	failed := report.Suites[0].Cases[1]
+	if failed.Failure == nil || failed.Failure.Contents != "diff" || failed.Properties.Properties[0].Value != "3" {
+		t.Errorf("unexpected failed case: %+v", failed)
+	}
+	if report.Suites[1].Cases[0].Skipped == nil {
+		t.Errorf("case-3 should be skipped")
+	}
+}
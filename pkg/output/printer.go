@@ -19,15 +19,66 @@ package output
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pterm/pterm"
 )
 
+// MaxFailureDiffs is the max number of the distinct failure diffs of a failed case to print and report,
+// the final error of the case is always included, it's set by the `--failure-diffs` flag.
+var MaxFailureDiffs = 3
+
 // CaseResult represents the result of a verification case.
 type CaseResult struct {
-	Msg  string
-	Err  error
-	Skip bool
+	Name     string
+	Msg      string
+	Err      error
+	Skip     bool
+	Retries  int
+	Duration time.Duration
+	Attempts []*Attempt
+}
+
+// Attempt represents a single attempt to verify a case.
+type Attempt struct {
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// AddAttempt records an attempt which is started at the given time and ends now.
+func (r *CaseResult) AddAttempt(start time.Time, err error) {
+	r.Attempts = append(r.Attempts, &Attempt{Start: start, Duration: time.Since(start), Err: err})
+}
+
+// Summary returns the message of the result along with the total duration of the case.
+func (r *CaseResult) Summary() string {
+	return fmt.Sprintf("%s (%s)\n", strings.TrimRight(r.Msg, " :\n"), r.Duration.Round(time.Millisecond))
+}
+
+// FailureDiffs returns the last n distinct failure diffs of the attempts, the final error is always the last one.
+func (r *CaseResult) FailureDiffs(n int) []string {
+	if r.Err == nil {
+		return nil
+	}
+
+	diffs := []string{r.Err.Error()}
+	seen := map[string]bool{r.Err.Error(): true}
+	for idx := len(r.Attempts) - 1; idx >= 0 && len(diffs) < n; idx-- {
+		attempt := r.Attempts[idx]
+		if attempt.Err == nil || seen[attempt.Err.Error()] {
+			continue
+		}
+		seen[attempt.Err.Error()] = true
+		diffs = append(diffs, fmt.Sprintf("attempt #%d:\n%s", idx+1, attempt.Err.Error()))
+	}
+
+	// reverse the diffs to the order of attempts.
+	for i, j := 0, len(diffs)-1; i < j; i, j = i+1, j-1 {
+		diffs[i], diffs[j] = diffs[j], diffs[i]
+	}
+	return diffs
 }
 
 type Printer interface {
@@ -36,6 +87,7 @@ type Printer interface {
 	Warning(string)
 	Fail(string)
 	UpdateText(string)
+	FailCase(*CaseResult)
 	PrintResult([]*CaseResult) (int, int, int)
 }
 
@@ -99,6 +151,22 @@ func (p *printer) UpdateText(text string) {
 	p.spinner.UpdateText(text)
 }
 
+// FailCase prints the summary and the last distinct failure diffs of the failed case.
+func (p *printer) FailCase(cr *CaseResult) {
+	if p.batchOutput {
+		return
+	}
+
+	p.failCase(cr)
+}
+
+func (p *printer) failCase(cr *CaseResult) {
+	p.spinner.Warning(cr.Summary())
+	for _, diff := range cr.FailureDiffs(MaxFailureDiffs) {
+		p.spinner.Fail(diff)
+	}
+}
+
 // PrintResult prints the result of verification and the summary.
 // If bathOutput is false, will only print the summary.
 func (p *printer) PrintResult(caseRes []*CaseResult) (passNum, failNum, skipNum int) {
@@ -109,13 +177,12 @@ func (p *printer) PrintResult(caseRes []*CaseResult) (passNum, failNum, skipNum
 			if cr.Err == nil {
 				passNum++
 				if p.batchOutput {
-					p.spinner.Success(cr.Msg)
+					p.spinner.Success(cr.Summary())
 				}
 			} else {
 				failNum++
 				if p.batchOutput {
-					p.spinner.Warning(cr.Msg)
-					p.spinner.Fail(cr.Err.Error())
+					p.failCase(cr)
 				}
 			}
 		} else {
@@ -0,0 +1,54 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCaseResult_FailureDiffs(t *testing.T) {
+	result := &CaseResult{}
+	for _, diff := range []string{"diff-1", "diff-2", "diff-2", "diff-3", "diff-1", "diff-4"} {
+		result.AddAttempt(time.Now(), fmt.Errorf("%s", diff))
+	}
+	result.Err = result.Attempts[len(result.Attempts)-1].Err
+
+	want := []string{"attempt #4:\ndiff-3", "attempt #5:\ndiff-1", "diff-4"}
+	if got := result.FailureDiffs(3); !reflect.DeepEqual(got, want) {
+		t.Errorf("FailureDiffs() = %q, want %q", got, want)
+	}
+	// the final error is always included, however small the limit is.
+	for _, n := range []int{0, 1} {
+		if got, want := result.FailureDiffs(n), []string{"diff-4"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("FailureDiffs(%d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := (&CaseResult{}).FailureDiffs(3); got != nil {
+		t.Errorf("FailureDiffs() of the succeeded case = %q, want nil", got)
+	}
+}
+
+func TestCaseResult_Summary(t *testing.T) {
+	result := &CaseResult{Msg: "failed to verify case, retried 2 time(s):", Duration: 1500 * time.Millisecond}
+	if got, want := result.Summary(), "failed to verify case, retried 2 time(s) (1.5s)\n"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,92 @@
+// Licensed to Apache Software Foundation (ASF) under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Apache Software Foundation (ASF) licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	ReportJUnit = "junit"
+)
+
+// Reporter writes the case results of the test suites to a report.
+type Reporter interface {
+	// Report adds the results of the suite to the report, the suites reported
+	// before are kept, so the report contains all the suites of the run.
+	Report(suite string, results []*CaseResult) error
+}
+
+var (
+	reporters []Reporter
+	// variant is the name of the current matrix combination, it's added to the suite names to tell the combinations apart.
+	variant string
+)
+
+// InitReporters creates the reporters from the options, each option should be in the format of `<type>=<path>`.
+func InitReporters(options []string) error {
+	reporters = make([]Reporter, 0, len(options))
+	for _, option := range options {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 || kv[1] == "" {
+			return fmt.Errorf("report option should be in the format of <type>=<path>, but was %s", option)
+		}
+
+		switch kind, path := kv[0], kv[1]; kind {
+		case ReportJUnit:
+			reporters = append(reporters, NewJUnitReporter(path))
+		default:
+			return fmt.Errorf("unsupported report type: %s", kind)
+		}
+	}
+	return nil
+}
+
+// AddReporter adds the reporter besides the ones created from the options.
+func AddReporter(reporter Reporter) {
+	reporters = append(reporters, reporter)
+}
+
+// RemoveReporter removes the reporter added before.
+func RemoveReporter(reporter Reporter) {
+	for idx := range reporters {
+		if reporters[idx] == reporter {
+			reporters = append(reporters[:idx], reporters[idx+1:]...)
+			return
+		}
+	}
+}
+
+// SetVariant sets the name of the current matrix combination, the suites reported after are named with it.
+func SetVariant(name string) {
+	variant = name
+}
+
+// Report adds the results of the suite to all the reporters.
+func Report(suite string, results []*CaseResult) error {
+	if variant != "" {
+		suite = fmt.Sprintf("%s [%s]", suite, variant)
+	}
+	for _, reporter := range reporters {
+		if err := reporter.Report(suite, results); err != nil {
+			return err
+		}
+	}
+	return nil
+}